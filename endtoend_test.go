@@ -12,11 +12,20 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 )
 
+// runGo is the go command used to run the generated code. string-enumer itself must be built and run with Go 1.18,
+// so code generated for newer Go versions is otherwise skipped. Set STRING_ENUMER_TEST_GO to the go command of a newer version to run it
+var runGo = "go"
+
 func TestEndToEnd(t *testing.T) {
+	if goCommand := os.Getenv("STRING_ENUMER_TEST_GO"); goCommand != "" {
+		runGo = goCommand
+	}
+
 	tmpDir, err := ioutil.TempDir("", "string-enumer")
 	if err != nil {
 		t.Fatal(err)
@@ -39,11 +48,58 @@ func TestEndToEnd(t *testing.T) {
 	for _, name := range names {
 		name := name
 		t.Run(name, func(t *testing.T) {
+			if info, err := os.Stat(filepath.Join("testdata", name)); err == nil && info.IsDir() {
+				compileAndRunModule(t, tmpDir, binPath, name)
+				return
+			}
 			compileAndRun(t, tmpDir, binPath, name)
 		})
 	}
 }
 
+// compileAndRunModule runs the code generation for a directory with a whole module,
+// used when the tested code needs to import other packages
+func compileAndRunModule(t *testing.T, dir, binPath, dirName string) {
+	t.Logf("run module: %s\n", dirName)
+
+	moduleDir := filepath.Join(dir, dirName)
+
+	// Copy the module to the temporary directory
+	err := copyDir(moduleDir, filepath.Join("testdata", dirName))
+	if err != nil {
+		t.Fatalf("copying module to temporary directory: %s", err)
+	}
+
+	// The generated code may use features of the Go version of the module
+	if err := requireGoVersion(t, filepath.Join(moduleDir, "go.mod")); err != nil {
+		t.Fatalf("reading the go version of the module: %s", err)
+	}
+
+	// Get parameters (except input and output file to be used)
+	extraParameters, err := getExtraParameters(filepath.Join(moduleDir, "main.go"))
+	if err != nil {
+		t.Fatalf("reading extra parameters: %s", err)
+	}
+
+	// Run the code generation
+	params := []string{"--output", "generated.go", "."}
+	params = append(params, extraParameters...)
+	err = runIn(moduleDir, binPath, params...)
+	if err != nil {
+		t.Fatalf("could not run string-enumer: %s", err)
+	}
+
+	if err := goFmtVerify(filepath.Join(moduleDir, "generated.go")); err != nil {
+		t.Errorf("could not verify that code is go formated: %s", err)
+	}
+
+	// Run the main package of the module, with the generated code attached
+	err = runIn(moduleDir, runGo, "run", ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func compileAndRun(t *testing.T, dir, binPath, fileName string) {
 	t.Logf("run: %s\n", fileName)
 
@@ -76,11 +132,29 @@ func compileAndRun(t *testing.T, dir, binPath, fileName string) {
 		t.Errorf("could not verify that code is go formated: %s", err)
 	}
 
+	runParameters, err := getRunParameters(sourcePath)
+	if err != nil {
+		t.Fatalf("reading run parameters: %s", err)
+	}
+
 	// Run the main() function in the source file, with the generated code attached
-	err = run("go", "run", sourcePath, outputPath)
+	err = run(runGo, append(append([]string{"run"}, runParameters...), sourcePath, outputPath)...)
 	if err != nil {
 		t.Fatal(err)
 	}
+
+	// Run the generated tests (with the seed corpus of any fuzz targets), if any was generated
+	testPath := filepath.Join(dir, outputName+"_output_test.go")
+	if _, err := os.Stat(testPath); err == nil {
+		if err := goFmtVerify(testPath); err != nil {
+			t.Errorf("could not verify that test code is go formated: %s", err)
+		}
+
+		err = run(runGo, "test", sourcePath, outputPath, testPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
 }
 
 var extraParameterRegexp = regexp.MustCompile("// extra-parameters: ([^\n]+)")
@@ -100,6 +174,63 @@ func getExtraParameters(filepath string) ([]string, error) {
 	return strings.Split(string(match[1]), " "), nil
 }
 
+var runParameterRegexp = regexp.MustCompile("// run-parameters: ([^\n]+)")
+
+// getRunParameters gets parameters to go run specified in a source file, e.g. -race
+func getRunParameters(filepath string) ([]string, error) {
+	b, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	match := runParameterRegexp.FindSubmatch(b)
+	if match == nil {
+		return nil, nil
+	}
+
+	return strings.Split(string(match[1]), " "), nil
+}
+
+var goDirectiveRegexp = regexp.MustCompile(`(?m)^go (1\.\d+)`)
+
+// requireGoVersion skips the test if the go command is older than the go directive of the module
+func requireGoVersion(t *testing.T, goModPath string) error {
+	b, err := ioutil.ReadFile(goModPath)
+	if err != nil {
+		return err
+	}
+	match := goDirectiveRegexp.FindSubmatch(b)
+	if match == nil {
+		return nil
+	}
+
+	out, err := exec.Command(runGo, "env", "GOVERSION").Output()
+	if err != nil {
+		return err
+	}
+	required, err := goMinorVersion(string(match[1]))
+	if err != nil {
+		return err
+	}
+	current, err := goMinorVersion(strings.TrimPrefix(strings.TrimSpace(string(out)), "go"))
+	if err != nil {
+		return err
+	}
+	if current < required {
+		t.Skipf("the module requires go %s, but the go command is %s", match[1], strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// goMinorVersion returns the minor version of a Go 1 version, e.g. 21 for 1.21.3
+func goMinorVersion(version string) (int, error) {
+	v := strings.TrimPrefix(version, "1.")
+	if end := strings.IndexFunc(v, func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+		v = v[:end]
+	}
+	return strconv.Atoi(v)
+}
+
 // readDir reads and returns all files in a directory
 func readDir(path string) ([]string, error) {
 	fd, err := os.Open(path)
@@ -143,11 +274,33 @@ func copy(to, from string) error {
 	return err
 }
 
+// copyDir copies the from directory, recursively, to the to directory.
+func copyDir(to, from string) error {
+	return filepath.Walk(from, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(to, rel), 0755)
+		}
+		return copy(filepath.Join(to, rel), path)
+	})
+}
+
 // run runs a single command and returns an error if it does not succeed.
 // os/exec should have this function, to be honest.
 func run(name string, arg ...string) error {
+	return runIn(".", name, arg...)
+}
+
+// runIn runs a single command in a directory and returns an error if it does not succeed.
+func runIn(dir, name string, arg ...string) error {
 	cmd := exec.Command(name, arg...)
-	cmd.Dir = "."
+	cmd.Dir = dir
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
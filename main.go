@@ -13,9 +13,35 @@ import (
 )
 
 var (
-	types      = pflag.StringSliceP("type", "t", nil, "the type name(s), can be multiple, but at least on must be set")
-	text       = pflag.BoolP("text", "T", false, "if set, text unmarshaling methods will be generated. Default: false")
-	outputPath = pflag.StringP("output", "o", "", "output file name; default is stdout")
+	types            = pflag.StringSliceP("type", "t", nil, "the type name(s), can be multiple, but at least on must be set")
+	text             = pflag.BoolP("text", "T", false, "if set, text unmarshaling methods will be generated. Default: false")
+	env              = pflag.Bool("env", false, "if set, decoding methods for environment variable libraries will be generated")
+	html             = pflag.Bool("html", false, "if set, helpers for HTML select elements and form decoding will be generated")
+	completion       = pflag.Bool("completion", false, "if set, shell completion functions will be generated")
+	registry         = pflag.Bool("registry", false, "if set, the types will be registered in the registry package for runtime introspection")
+	generic          = pflag.Bool("generic", false, "if set, methods needed to use the types with the generic enum package will be generated")
+	orm              = pflag.Bool("orm", false, "if set, methods used by ent, GORM and database/sql will be generated")
+	format           = pflag.Bool("format", false, "if set, GoString and Format methods will be generated")
+	names            = pflag.Bool("names", false, "if set, functions to get and parse values by their constant names will be generated")
+	slogValue        = pflag.Bool("slog", false, "if set, LogValue methods for log/slog will be generated. Requires Go 1.21 or later")
+	slogRedact       = pflag.Bool("slog-redact", false, "used with --slog; if set, invalid values are redacted by the LogValue methods")
+	validate         = pflag.Bool("validate", false, "if set, a Validate method returning a typed error for invalid values will be generated")
+	list             = pflag.Bool("list", false, "if set, a list type with text and Postgres array marshaling will be generated")
+	listSeparator    = pflag.String("list-separator", ",", "the separator of list values in text")
+	listDedupe       = pflag.Bool("list-dedupe", false, "if set, duplicate values are removed when lists are unmarshaled")
+	listOrder        = pflag.String("list-order", stringenumer.ListOrderInput, "the order of unmarshaled list values, \"input\" or \"declaration\"")
+	enumMap          = pflag.Bool("map", false, "if set, a generic map type with a value for every value of the type will be generated")
+	hierarchy        = pflag.String("hierarchy", "", "the separator of hierarchical values, e.g. \".\"; if set, helpers to navigate the hierarchy will be generated")
+	suggestThreshold = pflag.Int("suggest-threshold", stringenumer.DefaultSuggestionThreshold, "the maximum number of values of a type for which invalid value errors suggest the closest values; 0 turns suggestions off")
+	open             = pflag.Bool("open", false, "if set, values can be registered at runtime in addition to the declared constants")
+	proto            = pflag.StringToString("proto", nil, "protobuf enum to generate conversions to and from, as type=import/path.Type, can be multiple")
+	protoMatching    = pflag.String("proto-match", stringenumer.ProtoMatchName, "how values are matched with protobuf enum values, \"name\" (constant name without type prefix) or \"value\"")
+	nameTemplates    = pflag.StringToString("name", nil, "naming template of a generated identifier, as kind=template, e.g. values={{.Name}}List, can be multiple. See the README for all kinds")
+	structValidation = pflag.Bool("struct-validate", false, "if set, Validate methods will be generated for all structs in the package with fields of the types")
+	goVersion        = pflag.String("go-version", "", "the Go version, e.g. 1.21, that generated code targets; default is the go directive of the module")
+	outputPath       = pflag.StringP("output", "o", "", "output file name; default is stdout")
+	check            = pflag.Bool("check", false, "used with annotate and doc; if set, no file is changed, but the command fails if any doc comment is missing or outdated")
+	fuzz             = pflag.Bool("fuzz", false, "if set, fuzz tests will be generated into a _test.go file next to the output file. Requires --output")
 )
 
 // Usage is a replacement usage function for the flags package.
@@ -23,6 +49,9 @@ func Usage() {
 	fmt.Fprintf(os.Stderr, "Usage of string-enumer:\n")
 	fmt.Fprintf(os.Stderr, "\tstring-enumer [flags] --type T --type T2 [directory]\n")
 	fmt.Fprintf(os.Stderr, "\tstring-enumer [flags] --type T --type T2 files... # Must be a single package\n")
+	fmt.Fprintf(os.Stderr, "\tstring-enumer annotate [--check] --type T --type T2 [directory] # Updates kubebuilder markers\n")
+	fmt.Fprintf(os.Stderr, "\tstring-enumer doc [--check] --type T --type T2 [directory] # Updates the list of values in the type docs\n")
+	fmt.Fprintf(os.Stderr, "A directory named annotate or doc must be given as ./annotate or ./doc\n")
 	fmt.Fprintf(os.Stderr, "For more information, see:\n")
 	fmt.Fprintf(os.Stderr, "\thttps://github.com/lindell/string-enumer\n")
 	fmt.Fprintf(os.Stderr, "Flags:\n")
@@ -40,37 +69,131 @@ func main() {
 	}
 
 	args := pflag.Args()
+	var mode string
+	// A first argument of annotate or doc is always a subcommand, a directory with that name must be given as e.g. ./annotate
+	if len(args) > 0 && (args[0] == "annotate" || args[0] == "doc") {
+		mode, args = args[0], args[1:]
+	}
+	if len(args) == 0 && mode != "" {
+		if info, err := os.Stat(mode); err == nil && info.IsDir() {
+			fmt.Fprintf(os.Stderr, "%s is a subcommand, use ./%s to generate code for the directory\n", mode, mode)
+			os.Exit(2)
+		}
+	}
 	if len(args) == 0 {
 		fmt.Fprintln(os.Stderr, "a directory or file must be defined")
 		pflag.Usage()
 		os.Exit(2)
 	}
 
-	r, err := stringenumer.Generate(
+	switch mode {
+	case "annotate":
+		rewriteDocs(stringenumer.Annotate, stringenumer.CheckAnnotations, "kubebuilder enum validation marker",
+			stringenumer.Paths(args...), stringenumer.TypeNames(*types...))
+		return
+	case "doc":
+		rewriteDocs(stringenumer.Document, stringenumer.CheckDocs, "list of values in the type doc comment",
+			stringenumer.Paths(args...), stringenumer.TypeNames(*types...))
+		return
+	}
+
+	if *fuzz && *outputPath == "" {
+		fmt.Fprintln(os.Stderr, "--fuzz requires --output to be set")
+		pflag.Usage()
+		os.Exit(2)
+	}
+
+	options := []stringenumer.Option{
 		stringenumer.Paths(args...),
 		stringenumer.TypeNames(*types...),
 		stringenumer.TextUnmarshaling(*text),
-	)
+		stringenumer.EnvDecoding(*env),
+		stringenumer.HTMLOptions(*html),
+		stringenumer.Completions(*completion),
+		stringenumer.Registry(*registry),
+		stringenumer.Generic(*generic),
+		stringenumer.ORM(*orm),
+		stringenumer.Formatting(*format),
+		stringenumer.Names(*names),
+		stringenumer.Validate(*validate),
+		stringenumer.LogValue(*slogValue),
+		stringenumer.LogRedaction(*slogRedact),
+		stringenumer.List(*list),
+		stringenumer.ListSeparator(*listSeparator),
+		stringenumer.ListDedupe(*listDedupe),
+		stringenumer.ListOrder(*listOrder),
+		stringenumer.Map(*enumMap),
+		stringenumer.Hierarchy(*hierarchy),
+		stringenumer.Open(*open),
+		stringenumer.SuggestionThreshold(*suggestThreshold),
+		stringenumer.ProtoEnums(*proto),
+		stringenumer.ProtoMatching(*protoMatching),
+		stringenumer.StructValidation(*structValidation),
+		stringenumer.NameTemplates(*nameTemplates),
+		stringenumer.GoVersion(*goVersion),
+	}
+
+	r, err := stringenumer.Generate(options...)
 	if err != nil {
 		log.Fatalln(err)
 	}
+	if err := write(*outputPath, r); err != nil {
+		log.Fatalln(err)
+	}
+
+	if *fuzz {
+		r, err := stringenumer.GenerateFuzzTests(options...)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if err := write(strings.TrimSuffix(*outputPath, ".go")+"_test.go", r); err != nil {
+			log.Fatalln(err)
+		}
+	}
+}
 
+// rewriteDocs updates the doc comments of the types, or only checks them if --check is set
+func rewriteDocs(update, checkOnly func(...stringenumer.Option) ([]string, error), what string, options ...stringenumer.Option) {
+	if *check {
+		paths, err := checkOnly(options...)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		for _, path := range paths {
+			fmt.Fprintf(os.Stderr, "%s: %s is missing or outdated\n", path, what)
+		}
+		if len(paths) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	paths, err := update(options...)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	for _, path := range paths {
+		fmt.Println(path)
+	}
+}
+
+// write writes the generated code to the path, or to stdout if no path is set
+func write(path string, r io.Reader) error {
 	var output io.Writer
-	if *outputPath == "" {
+	if path == "" {
 		output = os.Stdout
 	} else {
-		file, err := os.OpenFile(*outputPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
-		output = file
-		defer file.Close()
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
 		if err != nil {
-			log.Fatalln(err)
+			return err
 		}
+		defer file.Close()
+		output = file
 	}
 
 	input := io.MultiReader(generateDontEdit(), r)
-	if _, err := io.Copy(output, input); err != nil {
-		log.Fatalln(err)
-	}
+	_, err := io.Copy(output, input)
+	return err
 }
 
 func generateDontEdit() io.Reader {
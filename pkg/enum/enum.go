@@ -0,0 +1,85 @@
+// Package enum contains generic helpers for enum types generated by string-enumer with the --generic option
+//
+//	country, err := enum.Parse[Country]("CA")
+//	countries := enum.Values[Country]()
+package enum
+
+import (
+	"fmt"
+)
+
+// Enum is the interface of enum types generated with the --generic option
+type Enum[T ~string] interface {
+	~string
+	// Valid validates if a value is a valid value of the type
+	Valid() bool
+	// AllValues returns all valid values of the type in declaration order, the receiver is not used
+	AllValues() []T
+}
+
+// Values returns all valid values of an enum type in declaration order
+func Values[T Enum[T]]() []T {
+	var zero T
+	return zero.AllValues()
+}
+
+// Parse takes a text, verifies that it is a valid value of the enum type and returns it
+func Parse[T Enum[T]](text string) (T, error) {
+	v := T(text)
+	if !v.Valid() {
+		var zero T
+		return zero, fmt.Errorf("not valid value for %T: %s", v, text)
+	}
+	return v, nil
+}
+
+// Set is a set of values of an enum type
+type Set[T Enum[T]] map[T]struct{}
+
+// NewSet creates a set of the values, and returns an error if any of the values is not valid
+func NewSet[T Enum[T]](values ...T) (Set[T], error) {
+	set := make(Set[T], len(values))
+	for _, v := range values {
+		if !v.Valid() {
+			return nil, fmt.Errorf("not valid value for %T: %s", v, string(v))
+		}
+		set[v] = struct{}{}
+	}
+	return set, nil
+}
+
+// Contains returns true if the value is in the set
+func (s Set[T]) Contains(v T) bool {
+	_, ok := s[v]
+	return ok
+}
+
+// Values returns all values in the set in declaration order
+func (s Set[T]) Values() []T {
+	values := make([]T, 0, len(s))
+	for _, v := range Values[T]() {
+		if s.Contains(v) {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// Description is a JSON schema like description of an enum type
+type Description struct {
+	Type string   `json:"type"`
+	Enum []string `json:"enum"`
+}
+
+// Describe returns a JSON schema like description of an enum type
+func Describe[T Enum[T]]() Description {
+	values := Values[T]()
+	description := Description{
+		Type: "string",
+		Enum: make([]string, len(values)),
+	}
+	for i, v := range values {
+		description.Enum[i] = string(v)
+	}
+	return description
+}
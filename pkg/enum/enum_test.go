@@ -0,0 +1,68 @@
+package enum_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/lindell/string-enumer/pkg/enum"
+)
+
+type country string
+
+const (
+	countryCanada country = "CA"
+	countrySweden country = "SE"
+)
+
+func (v country) Valid() bool {
+	return v == countryCanada || v == countrySweden
+}
+
+func (country) AllValues() []country {
+	return []country{countryCanada, countrySweden}
+}
+
+func TestValues(t *testing.T) {
+	if values := enum.Values[country](); fmt.Sprint(values) != "[CA SE]" {
+		t.Fatalf("wrong values: %v", values)
+	}
+}
+
+func TestParse(t *testing.T) {
+	v, err := enum.Parse[country]("SE")
+	if err != nil || v != countrySweden {
+		t.Fatalf("could not parse: %v", err)
+	}
+
+	if _, err := enum.Parse[country]("US"); err == nil {
+		t.Fatal("could parse an invalid value")
+	}
+}
+
+func TestSet(t *testing.T) {
+	set, err := enum.NewSet(countrySweden, countryCanada, countrySweden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !set.Contains(countryCanada) || set.Contains("US") {
+		t.Fatal("wrong set content")
+	}
+	if values := set.Values(); fmt.Sprint(values) != "[CA SE]" {
+		t.Fatalf("wrong set values: %v", values)
+	}
+
+	if _, err := enum.NewSet[country]("US"); err == nil {
+		t.Fatal("could create a set with an invalid value")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	b, err := json.Marshal(enum.Describe[country]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"type":"string","enum":["CA","SE"]}` {
+		t.Fatalf("wrong description: %s", b)
+	}
+}
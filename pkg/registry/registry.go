@@ -0,0 +1,97 @@
+// Package registry is a process-wide registry of enum types, used for runtime introspection.
+//
+// Code generated by string-enumer with the --registry option registers each type from an init function.
+// All functions are safe to use concurrently.
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Value is a single value of an enum type
+type Value struct {
+	Name        string // The name of the constant
+	Value       string
+	Description string
+}
+
+// Enum describes an enum type and all of its values
+type Enum struct {
+	Name    string // The name of the type
+	PkgPath string // The import path of the package where the type is declared
+	Values  []Value
+	Valid   func(value string) bool // Validates if a value is a valid value of the type
+}
+
+var (
+	mutex sync.RWMutex
+	enums = map[key]Enum{}
+)
+
+type key struct {
+	pkgPath string
+	name    string
+}
+
+// Register adds an enum type to the registry.
+// It panics if a type with the same package path and name is already registered
+func Register(e Enum) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	k := key{pkgPath: e.PkgPath, name: e.Name}
+	if _, ok := enums[k]; ok {
+		panic(fmt.Sprintf("registry: %s.%s is already registered", e.PkgPath, e.Name))
+	}
+	enums[k] = e.clone()
+}
+
+// Lookup returns the registered enum type with the package path and name
+func Lookup(pkgPath, name string) (Enum, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	e, ok := enums[key{pkgPath: pkgPath, name: name}]
+	if !ok {
+		return Enum{}, false
+	}
+	return e.clone(), true
+}
+
+// Enums returns all registered enum types, sorted by package path and name
+func Enums() []Enum {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	ret := make([]Enum, 0, len(enums))
+	for _, e := range enums {
+		ret = append(ret, e.clone())
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].PkgPath != ret[j].PkgPath {
+			return ret[i].PkgPath < ret[j].PkgPath
+		}
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
+
+// Range calls fn for every registered enum type, sorted by package path and name, until fn returns false.
+// Types registered while ranging are not included
+func Range(fn func(Enum) bool) {
+	for _, e := range Enums() {
+		if !fn(e) {
+			return
+		}
+	}
+}
+
+// clone returns a copy of the enum that does not share any values with the original
+func (e Enum) clone() Enum {
+	values := make([]Value, len(e.Values))
+	copy(values, e.Values)
+	e.Values = values
+	return e
+}
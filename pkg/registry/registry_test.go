@@ -0,0 +1,95 @@
+package registry
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func reset() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	enums = map[key]Enum{}
+}
+
+func TestRegistry(t *testing.T) {
+	defer reset()
+
+	Register(Enum{
+		Name:    "Country",
+		PkgPath: "example.com/geo",
+		Values: []Value{
+			{Name: "CountryCanada", Value: "CA", Description: "Canada"},
+			{Name: "CountrySweden", Value: "SE"},
+		},
+		Valid: func(value string) bool { return value == "CA" || value == "SE" },
+	})
+	Register(Enum{Name: "Color", PkgPath: "example.com/paint"})
+	Register(Enum{Name: "Continent", PkgPath: "example.com/geo"})
+
+	e, ok := Lookup("example.com/geo", "Country")
+	if !ok {
+		t.Fatal("could not lookup Country")
+	}
+	if len(e.Values) != 2 || e.Values[0].Description != "Canada" || !e.Valid("SE") || e.Valid("US") {
+		t.Fatalf("wrong enum: %+v", e)
+	}
+
+	// Changing the returned values should not change the registry
+	e.Values[0].Value = "changed"
+	if e, _ := Lookup("example.com/geo", "Country"); e.Values[0].Value != "CA" {
+		t.Fatal("the registry could be changed")
+	}
+
+	if _, ok := Lookup("example.com/geo", "Color"); ok {
+		t.Fatal("should not lookup an unregistered type")
+	}
+
+	var names []string
+	Range(func(e Enum) bool {
+		names = append(names, e.PkgPath+"."+e.Name)
+		return len(names) < 2
+	})
+	if fmt.Sprint(names) != "[example.com/geo.Continent example.com/geo.Country]" {
+		t.Fatalf("wrong range order: %v", names)
+	}
+
+	if len(Enums()) != 3 {
+		t.Fatalf("wrong number of enums: %d", len(Enums()))
+	}
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	defer reset()
+
+	Register(Enum{Name: "Country", PkgPath: "example.com/geo"})
+	defer func() {
+		if recover() == nil {
+			t.Fatal("registering a type twice should panic")
+		}
+	}()
+	Register(Enum{Name: "Country", PkgPath: "example.com/geo"})
+}
+
+func TestConcurrency(t *testing.T) {
+	defer reset()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			Register(Enum{Name: fmt.Sprintf("Enum%d", i), PkgPath: "example.com/concurrent"})
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			Lookup("example.com/concurrent", fmt.Sprintf("Enum%d", i))
+			Range(func(Enum) bool { return true })
+		}(i)
+	}
+	wg.Wait()
+
+	if len(Enums()) != 20 {
+		t.Fatalf("wrong number of enums: %d", len(Enums()))
+	}
+}
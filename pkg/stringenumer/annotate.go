@@ -0,0 +1,182 @@
+package stringenumer
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// kubebuilderEnumMarker is the beginning of a kubebuilder enum validation marker, after the comment prefix
+const kubebuilderEnumMarker = "+kubebuilder:validation:Enum="
+
+// markerCommentPrefixes are the comment prefixes of markers, with and without a space, that are both used
+var markerCommentPrefixes = []string{"// ", "//"}
+
+// isMarker returns true if the comment line is a marker, e.g. "// +kubebuilder:validation:Enum=a;b" or "//+optional"
+func isMarker(line string) bool {
+	return strings.HasPrefix(line, "//+") || strings.HasPrefix(line, "// +")
+}
+
+// Annotate inserts or updates a kubebuilder enum validation marker above the declaration of each type,
+// and returns the paths of all changed files
+func Annotate(options ...Option) ([]string, error) {
+	g, err := newGenerator(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := g.rewriteTypeDocs(g.kubebuilderMarker)
+	if err != nil {
+		return nil, err
+	}
+	return writeChanges(changes)
+}
+
+// CheckAnnotations returns the paths of all files where the kubebuilder enum validation marker
+// of any of the types is missing or outdated, without changing any file
+func CheckAnnotations(options ...Option) ([]string, error) {
+	g, err := newGenerator(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := g.rewriteTypeDocs(g.kubebuilderMarker)
+	if err != nil {
+		return nil, err
+	}
+	return sortedPaths(changes), nil
+}
+
+// kubebuilderMarker updates the kubebuilder enum validation marker in the doc comment of a type,
+// or adds it to the end of the doc comment if it does not exist
+func (g *generator) kubebuilderMarker(typeName string, doc []string) []string {
+	values := make([]string, len(g.values[typeName]))
+	for i, v := range g.values[typeName] {
+		values[i] = markerValue(v.value)
+	}
+	marker := kubebuilderEnumMarker + strings.Join(values, ";")
+
+	updated := make([]string, len(doc))
+	copy(updated, doc)
+	for i, line := range updated {
+		// An existing marker is updated with the same comment prefix
+		for _, prefix := range markerCommentPrefixes {
+			if strings.HasPrefix(line, prefix+kubebuilderEnumMarker) {
+				updated[i] = prefix + marker
+				return updated
+			}
+		}
+	}
+	return append(updated, markerCommentPrefixes[0]+marker)
+}
+
+// markerValue quotes a value in a marker if it contains a character that would otherwise split it
+// into several values or arguments
+func markerValue(value string) string {
+	if value == "" || strings.ContainsAny(value, ";, \"`") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// docEdit is a replacement of a byte range of a source file
+type docEdit struct {
+	start, end int
+	text       string
+}
+
+// rewriteTypeDocs updates the doc comment of the declaration of each type, one comment per line,
+// and returns the new content of all changed files by their path
+func (g *generator) rewriteTypeDocs(update func(typeName string, doc []string) []string) (map[string][]byte, error) {
+	changes := map[string][]byte{}
+	for _, f := range g.pkg.files {
+		src, err := ioutil.ReadFile(f.path)
+		if err != nil {
+			return nil, err
+		}
+		tokenFile := g.pkg.fset.File(f.file.Pos())
+
+		var edits []docEdit
+		for _, decl := range f.file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				tspec := spec.(*ast.TypeSpec) // Guaranteed to succeed as this is TYPE.
+				if _, ok := g.values[tspec.Name.Name]; !ok {
+					continue
+				}
+
+				// The doc comment of a single type declaration belongs to the declaration
+				doc, anchor := decl.Doc, decl.Pos()
+				if decl.Lparen.IsValid() {
+					doc, anchor = tspec.Doc, tspec.Pos()
+				}
+
+				var lines []string
+				if doc != nil {
+					for _, comment := range doc.List {
+						lines = append(lines, comment.Text)
+					}
+				}
+				updated := update(tspec.Name.Name, lines)
+				if strings.Join(lines, "\n") == strings.Join(updated, "\n") {
+					continue
+				}
+
+				offset := tokenFile.Offset(anchor)
+				indent := string(src[tokenFile.Offset(tokenFile.LineStart(tokenFile.Line(anchor))):offset])
+				text := strings.Join(updated, "\n"+indent)
+				if doc == nil {
+					edits = append(edits, docEdit{start: offset, end: offset, text: text + "\n" + indent})
+				} else {
+					edits = append(edits, docEdit{start: tokenFile.Offset(doc.Pos()), end: tokenFile.Offset(doc.End()), text: text})
+				}
+			}
+		}
+		if len(edits) == 0 {
+			continue
+		}
+
+		// Apply the edits from the end of the file, to not change the offsets of the other edits
+		changed := src
+		for i := len(edits) - 1; i >= 0; i-- {
+			e := edits[i]
+			changed = append(changed[:e.start:e.start], append([]byte(e.text), changed[e.end:]...)...)
+		}
+		formatted, err := format.Source(changed)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(formatted, src) {
+			changes[f.path] = formatted
+		}
+	}
+	return changes, nil
+}
+
+// writeChanges writes the new content of all changed files, and returns their paths
+func writeChanges(changes map[string][]byte) ([]string, error) {
+	paths := sortedPaths(changes)
+	for _, path := range paths {
+		if err := ioutil.WriteFile(path, changes[path], 0644); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+func sortedPaths(changes map[string][]byte) []string {
+	paths := make([]string, 0, len(changes))
+	for path := range changes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
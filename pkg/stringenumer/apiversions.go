@@ -0,0 +1,197 @@
+package stringenumer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// hasAPIVersions returns true if any of the values is introduced or removed in an API version
+func hasAPIVersions(values []value) bool {
+	for _, v := range values {
+		if _, ok := v.directives["since"]; ok {
+			return true
+		}
+		if _, ok := v.directives["until"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAPIVersion parses an API version, e.g. v3 or v3.1, into its numbers
+func parseAPIVersion(version string) ([]int, error) {
+	if !strings.HasPrefix(version, "v") {
+		return nil, fmt.Errorf("malformed API version %q, expected e.g. v3 or v3.1", version)
+	}
+	parts := strings.Split(version[1:], ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("malformed API version %q, expected e.g. v3 or v3.1", version)
+		}
+		numbers[i] = int(n)
+	}
+	return numbers, nil
+}
+
+// apiVersionBefore returns true if the API version a is before b, where missing numbers are 0
+func apiVersionBefore(a, b []int) bool {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			return x < y
+		}
+	}
+	return false
+}
+
+// apiVersionRange returns the parsed since and until directives of a value, nil if not set
+func apiVersionRange(v value) (since, until []int, err error) {
+	if s, ok := v.directives["since"]; ok {
+		if since, err = parseAPIVersion(s); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", v.name, err)
+		}
+	}
+	if u, ok := v.directives["until"]; ok {
+		if until, err = parseAPIVersion(u); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", v.name, err)
+		}
+	}
+	return since, until, nil
+}
+
+// validateAPIVersions ensures that the API versions of all values are well-formed, and that no range is inverted or empty
+func validateAPIVersions(values []value) error {
+	for _, v := range values {
+		since, until, err := apiVersionRange(v)
+		if err != nil {
+			return err
+		}
+		if since != nil && until != nil && !apiVersionBefore(since, until) {
+			return fmt.Errorf("%s: the API version range is empty, since %s is not before until %s", v.name, v.directives["since"], v.directives["until"])
+		}
+	}
+	return nil
+}
+
+// intList returns the numbers as a comma separated list
+func intList(numbers []int) string {
+	s := make([]string, len(numbers))
+	for i, n := range numbers {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ", ")
+}
+
+func (g *generator) buildAPIVersions(name string) {
+	g.addImport(`"fmt"`)
+	g.addImport(`"strconv"`)
+	g.addImport(`"strings"`)
+
+	parseVersion := g.ident("parse-api-version", name)
+	g.Printf("\n// %s parses an API version, e.g. v3 or v3.1, into its numbers, and returns false if it is malformed\n", parseVersion)
+	g.Printf("func %s(version string) ([]int, bool) {\n", parseVersion)
+	g.Printf("	if !strings.HasPrefix(version, \"v\") {\n")
+	g.Printf("		return nil, false\n")
+	g.Printf("	}\n")
+	g.Printf("	parts := strings.Split(version[1:], \".\")\n")
+	g.Printf("	numbers := make([]int, len(parts))\n")
+	g.Printf("	for i, part := range parts {\n")
+	g.Printf("		n, err := strconv.ParseUint(part, 10, 31)\n")
+	g.Printf("		if err != nil {\n")
+	g.Printf("			return nil, false\n")
+	g.Printf("		}\n")
+	g.Printf("		numbers[i] = int(n)\n")
+	g.Printf("	}\n")
+	g.Printf("	return numbers, true\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// ValidFor validates if a value is a valid %s in the API version, e.g. v3. Values are valid from the\n", name)
+	g.Printf("// version they are introduced in, and until, but not including, the version they are removed in:\n")
+	for _, v := range g.values[name] {
+		g.Printf("%s%s (%q)", valueListItem, v.name, v.value)
+		if since, ok := v.directives["since"]; ok {
+			g.Printf(" since %s", since)
+		}
+		if until, ok := v.directives["until"]; ok {
+			g.Printf(" until %s", until)
+		}
+		g.Printf("\n")
+	}
+	g.Printf("func (v %s) ValidFor(version string) bool {\n", name)
+	g.Printf("	parsed, ok := %s(version)\n", parseVersion)
+	g.Printf("	if !ok {\n")
+	g.Printf("		return false\n")
+	g.Printf("	}\n")
+	g.Printf("	// before returns true if the version is before the bound, where missing numbers are 0\n")
+	g.Printf("	before := func(bound ...int) bool {\n")
+	g.Printf("		for i := 0; i < len(parsed) || i < len(bound); i++ {\n")
+	g.Printf("			var a, b int\n")
+	g.Printf("			if i < len(parsed) {\n")
+	g.Printf("				a = parsed[i]\n")
+	g.Printf("			}\n")
+	g.Printf("			if i < len(bound) {\n")
+	g.Printf("				b = bound[i]\n")
+	g.Printf("			}\n")
+	g.Printf("			if a != b {\n")
+	g.Printf("				return a < b\n")
+	g.Printf("			}\n")
+	g.Printf("		}\n")
+	g.Printf("		return false\n")
+	g.Printf("	}\n")
+	g.Printf("	switch v {\n")
+	for _, v := range g.values[name] {
+		since, until, _ := apiVersionRange(v) // Validated in validateAPIVersions
+		var conditions []string
+		if since != nil {
+			conditions = append(conditions, fmt.Sprintf("!before(%s)", intList(since)))
+		}
+		if until != nil {
+			conditions = append(conditions, fmt.Sprintf("before(%s)", intList(until)))
+		}
+		if len(conditions) == 0 {
+			conditions = append(conditions, "true")
+		}
+		g.Printf("	case %s:\n", v.name)
+		g.Printf("		return %s\n", strings.Join(conditions, " && "))
+	}
+	g.Printf("	}\n")
+	g.Printf("	return false\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// %s returns a list of all %s values that are valid in the API version, in declaration order\n", g.ident("values-for", name), name)
+	g.Printf("func %s(version string) []%s {\n", g.ident("values-for", name), name)
+	g.Printf("	var values []%s\n", name)
+	g.Printf("	for _, v := range %s {\n", g.ident("all-values", name))
+	g.Printf("		if v.ValidFor(version) {\n")
+	g.Printf("			values = append(values, v)\n")
+	g.Printf("		}\n")
+	g.Printf("	}\n")
+	g.Printf("	return values\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// UnmarshalTextFor takes a text, verifies that it is a correct %s in the API version and unmarshals it.\n", name)
+	g.Printf("// The error of a value that is not valid in the version wraps %s\n", g.ident("invalid-error", name))
+	g.Printf("func (v *%s) UnmarshalTextFor(version string, text []byte) error {\n", name)
+	g.Printf("	parsed := %s(text)\n", name)
+	g.Printf("	if !parsed.ValidFor(version) {\n")
+	if g.generates("suggest", name) {
+		// A value of another version is not worth suggesting to itself
+		g.Printf("		if parsed.Valid() {\n")
+		g.Printf("			return fmt.Errorf(\"not valid in API version %%s: %%w\", version, %s{Value: string(text)})\n", g.ident("invalid-error", name))
+		g.Printf("		}\n")
+	}
+	g.Printf("		return fmt.Errorf(\"not valid in API version %%s: %%w\", version, %s)\n", g.invalidError(name, "string(text)"))
+	g.Printf("	}\n")
+	g.Printf("	*v = parsed\n")
+	g.Printf("	return nil\n")
+	g.Printf("}\n")
+}
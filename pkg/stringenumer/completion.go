@@ -0,0 +1,39 @@
+package stringenumer
+
+func (g *generator) buildCompletions(name string) {
+	g.addImport(`"strings"`)
+	g.Printf("\n// %s returns all %s values that starts with toComplete, to be used for shell completion.\n", g.ident("completions", name), name)
+	g.Printf("// Values with a description are returned in the \"value\\tdescription\" format used by cobra\n")
+	g.Printf("func %s(toComplete string) []string {\n", g.ident("completions", name))
+	g.Printf("	var completions []string\n")
+	if g.open {
+		g.Printf("	candidates := []struct{ value, description string }{\n")
+	} else {
+		g.Printf("	for _, completion := range []struct{ value, description string }{\n")
+	}
+	for _, v := range g.values[name] {
+		g.Printf("		{%q, %q},\n", v.value, v.description)
+	}
+	if g.open {
+		g.Printf("	}\n")
+		g.Printf("	%s.RLock()\n", g.ident("mutex", name))
+		g.Printf("	for _, v := range %s {\n", g.ident("registered-values", name))
+		g.Printf("		candidates = append(candidates, struct{ value, description string }{string(v), %s[v].Description})\n", g.ident("meta-map", name))
+		g.Printf("	}\n")
+		g.Printf("	%s.RUnlock()\n", g.ident("mutex", name))
+		g.Printf("	for _, completion := range candidates {\n")
+	} else {
+		g.Printf("	} {\n")
+	}
+	g.Printf("		if !strings.HasPrefix(completion.value, toComplete) {\n")
+	g.Printf("			continue\n")
+	g.Printf("		}\n")
+	g.Printf("		if completion.description == \"\" {\n")
+	g.Printf("			completions = append(completions, completion.value)\n")
+	g.Printf("		} else {\n")
+	g.Printf("			completions = append(completions, completion.value+\"\\t\"+completion.description)\n")
+	g.Printf("		}\n")
+	g.Printf("	}\n")
+	g.Printf("	return completions\n")
+	g.Printf("}\n")
+}
@@ -0,0 +1,85 @@
+package stringenumer
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+)
+
+// directivePrefix is the prefix of comments with directives to the generator, e.g. "// enum:id=7"
+const directivePrefix = "enum:"
+
+// knownDirectives contains all keys that can be set in directives
+var knownDirectives = map[string]struct{}{
+	"id":    {},
+	"since": {},
+	"until": {},
+}
+
+// isDirective returns true if the comment line is a directive, i.e. the directive prefix is immediately
+// followed by a known key and "=", so that e.g. "enum: legacy value" is kept as a description
+func isDirective(line string) bool {
+	if !strings.HasPrefix(line, directivePrefix) {
+		return false
+	}
+	split := strings.SplitN(strings.TrimPrefix(line, directivePrefix), "=", 2)
+	if len(split) != 2 {
+		return false
+	}
+	_, ok := knownDirectives[split[0]]
+	return ok
+}
+
+// constComments returns the comments of a constant declaration, the line comment before the doc comment
+func constComments(decl *ast.GenDecl, vspec *ast.ValueSpec) []*ast.CommentGroup {
+	comments := []*ast.CommentGroup{vspec.Comment, vspec.Doc}
+	if !decl.Lparen.IsValid() {
+		// The doc comment of a single constant declaration belongs to the declaration
+		comments = append(comments, decl.Doc)
+	}
+	return comments
+}
+
+// description returns the first line of the first comment that is not a directive
+func description(comments []*ast.CommentGroup) string {
+	for _, comment := range comments {
+		for _, line := range strings.Split(comment.Text(), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !isDirective(line) {
+				return line
+			}
+		}
+	}
+	return ""
+}
+
+// parseDirectives parses all directives in the comments, each being space separated key=value pairs
+func parseDirectives(comments []*ast.CommentGroup) (map[string]string, error) {
+	directives := map[string]string{}
+	for _, comment := range comments {
+		if comment == nil {
+			continue
+		}
+		for _, c := range comment.List {
+			text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			if !isDirective(text) {
+				continue
+			}
+			for _, field := range strings.Fields(strings.TrimPrefix(text, directivePrefix)) {
+				split := strings.SplitN(field, "=", 2)
+				if len(split) != 2 || split[1] == "" {
+					return nil, fmt.Errorf("malformed directive %q, expected key=value", field)
+				}
+				key, value := split[0], split[1]
+				if _, ok := knownDirectives[key]; !ok {
+					return nil, fmt.Errorf("unknown directive %q", key)
+				}
+				if _, ok := directives[key]; ok {
+					return nil, fmt.Errorf("the directive %q is set multiple times", key)
+				}
+				directives[key] = value
+			}
+		}
+	}
+	return directives, nil
+}
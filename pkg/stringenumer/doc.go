@@ -0,0 +1,89 @@
+package stringenumer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// valueListItem is the prefix of an item of the list of values in a doc comment
+const valueListItem = "//   - "
+
+// valueList returns the items of a doc comment list of all values of the type, with their constant name and description
+func (g *generator) valueList(typeName string) []string {
+	items := make([]string, len(g.values[typeName]))
+	for i, v := range g.values[typeName] {
+		items[i] = fmt.Sprintf("%s%s (%q)", valueListItem, v.name, v.value)
+		if v.description != "" {
+			items[i] += ": " + v.description
+		}
+	}
+	return items
+}
+
+// printValueList prints a doc comment list of all values of the type
+func (g *generator) printValueList(typeName string) {
+	for _, item := range g.valueList(typeName) {
+		g.Printf("%s\n", item)
+	}
+}
+
+// Document inserts or updates a list of all values in the doc comment of each type, and returns the paths of all changed files
+func Document(options ...Option) ([]string, error) {
+	g, err := newGenerator(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := g.rewriteTypeDocs(g.valuesDocSection)
+	if err != nil {
+		return nil, err
+	}
+	return writeChanges(changes)
+}
+
+// CheckDocs returns the paths of all files where the list of values in the doc comment of any of the types
+// is missing or outdated, without changing any file
+func CheckDocs(options ...Option) ([]string, error) {
+	g, err := newGenerator(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := g.rewriteTypeDocs(g.valuesDocSection)
+	if err != nil {
+		return nil, err
+	}
+	return sortedPaths(changes), nil
+}
+
+// valuesDocSection updates the list of values in the doc comment of a type, or adds it to the end of the doc comment,
+// before any markers, if it does not exist
+func (g *generator) valuesDocSection(typeName string, doc []string) []string {
+	heading := fmt.Sprintf("// Valid %s values:", typeName)
+	section := append([]string{heading}, g.valueList(typeName)...)
+
+	for i, line := range doc {
+		if line != heading {
+			continue
+		}
+		end := i + 1
+		for end < len(doc) && strings.HasPrefix(doc[end], valueListItem) {
+			end++
+		}
+		return append(append(append([]string{}, doc[:i]...), section...), doc[end:]...)
+	}
+
+	// Markers, e.g. +kubebuilder, are kept last
+	insert := len(doc)
+	for insert > 0 && isMarker(doc[insert-1]) {
+		insert--
+	}
+	before, after := doc[:insert], doc[insert:]
+	if len(before) > 0 && before[len(before)-1] != "//" {
+		section = append([]string{"//"}, section...)
+	}
+	if len(after) > 0 {
+		section = append(section, "//")
+	}
+	return append(append(append([]string{}, before...), section...), after...)
+}
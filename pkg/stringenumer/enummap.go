@@ -0,0 +1,161 @@
+package stringenumer
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+)
+
+// mapParamNames returns the names of the parameters of the map constructor, one for each value of the type.
+// The short names are used if all of them are valid and unique, otherwise v0, v1 and so on
+func mapParamNames(typeName string, values []value) []string {
+	reserved := map[string]struct{}{"V": {}, "any": {}, "m": {}, "fns": {}, "i": {}, "fn": {}, "_": {}}
+	names := make([]string, len(values))
+	for i, v := range values {
+		name := lowerFirst(shortName(typeName, v))
+		if _, ok := reserved[name]; ok || !token.IsIdentifier(name) {
+			for i := range names {
+				names[i] = fmt.Sprintf("v%d", i)
+			}
+			return names
+		}
+		reserved[name] = struct{}{}
+		names[i] = name
+	}
+	return names
+}
+
+func (g *generator) buildMap(name string) {
+	g.addImport(`"encoding/json"`)
+	g.addImport(`"fmt"`)
+	g.addImport(`"strings"`)
+
+	values := g.values[name]
+	enumMap := g.ident("map", name)
+	count := g.ident("count", name)
+	// Registered values of open types are valid, but not keys of the map
+	keyKind := "valid"
+	if g.open {
+		keyKind = "declared"
+	}
+
+	g.Printf("\n// %s maps every %s value to a value of type V. It is backed by an array indexed by declaration order,\n", enumMap, name)
+	g.Printf("// so it is copied by assignment, and it always has a value for every %s value\n", name)
+	g.Printf("type %s[V any] struct {\n", enumMap)
+	g.Printf("	values [%s]V\n", count)
+	g.Printf("}\n\n")
+
+	params := mapParamNames(name, values)
+	g.Printf("// %s creates a %s with the value returned by a function for every %s value, which all must be set:\n", g.ident("new-map", name), enumMap, name)
+	for i, v := range values {
+		g.Printf("//   - %s for %s\n", params[i], v.name)
+	}
+	g.Printf("func %s[V any](%s func() V) %s[V] {\n", g.ident("new-map", name), strings.Join(params, ", "), enumMap)
+	g.Printf("	fns := [%s]func() V{%s}\n", count, strings.Join(params, ", "))
+	g.Printf("	var m %s[V]\n", enumMap)
+	g.Printf("	for i, fn := range fns {\n")
+	g.Printf("		m.values[i] = fn()\n")
+	g.Printf("	}\n")
+	g.Printf("	return m\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// index returns the position of the key in declaration order, and false if it is not a %s %s\n", keyKind, name)
+	g.Printf("func (%s[V]) index(key %s) (int, bool) {\n", enumMap, name)
+	g.Printf("	switch key {\n")
+	for i, v := range values {
+		g.Printf("	case %s:\n", v.name)
+		g.Printf("		return %d, true\n", i)
+	}
+	g.Printf("	}\n")
+	g.Printf("	return 0, false\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// Get returns the value of the key, and false if the key is not a %s %s\n", keyKind, name)
+	g.Printf("func (m %s[V]) Get(key %s) (V, bool) {\n", enumMap, name)
+	g.Printf("	i, ok := m.index(key)\n")
+	g.Printf("	if !ok {\n")
+	g.Printf("		var zero V\n")
+	g.Printf("		return zero, false\n")
+	g.Printf("	}\n")
+	g.Printf("	return m.values[i], true\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// Set sets the value of the key, and returns false if the key is not a %s %s\n", keyKind, name)
+	g.Printf("func (m *%s[V]) Set(key %s, value V) bool {\n", enumMap, name)
+	g.Printf("	i, ok := m.index(key)\n")
+	g.Printf("	if !ok {\n")
+	g.Printf("		return false\n")
+	g.Printf("	}\n")
+	g.Printf("	m.values[i] = value\n")
+	g.Printf("	return true\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// Range calls fn for every %s value and its value in declaration order, until fn returns false\n", name)
+	g.Printf("func (m %s[V]) Range(fn func(key %s, value V) bool) {\n", enumMap, name)
+	g.Printf("	for i, key := range %s {\n", g.ident("all-values", name))
+	g.Printf("		if !fn(key, m.values[i]) {\n")
+	g.Printf("			return\n")
+	g.Printf("		}\n")
+	g.Printf("	}\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// MarshalJSON marshals the map as an object with every %s value as a key, in declaration order\n", name)
+	g.Printf("func (m %s[V]) MarshalJSON() ([]byte, error) {\n", enumMap)
+	g.Printf("	var b strings.Builder\n")
+	g.Printf("	b.WriteString(\"{\")\n")
+	g.Printf("	for i, key := range %s {\n", g.ident("all-values", name))
+	g.Printf("		if i > 0 {\n")
+	g.Printf("			b.WriteString(\",\")\n")
+	g.Printf("		}\n")
+	g.Printf("		k, err := json.Marshal(string(key))\n")
+	g.Printf("		if err != nil {\n")
+	g.Printf("			return nil, err\n")
+	g.Printf("		}\n")
+	g.Printf("		v, err := json.Marshal(m.values[i])\n")
+	g.Printf("		if err != nil {\n")
+	g.Printf("			return nil, err\n")
+	g.Printf("		}\n")
+	g.Printf("		b.Write(k)\n")
+	g.Printf("		b.WriteString(\":\")\n")
+	g.Printf("		b.Write(v)\n")
+	g.Printf("	}\n")
+	g.Printf("	b.WriteString(\"}\")\n")
+	g.Printf("	return []byte(b.String()), nil\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// UnmarshalJSON unmarshals an object with every %s value as a key. Unknown keys are rejected,\n", name)
+	g.Printf("// and all missing keys are reported\n")
+	g.Printf("func (m *%s[V]) UnmarshalJSON(data []byte) error {\n", enumMap)
+	g.Printf("	var raw map[string]json.RawMessage\n")
+	g.Printf("	if err := json.Unmarshal(data, &raw); err != nil {\n")
+	g.Printf("		return err\n")
+	g.Printf("	}\n")
+	g.Printf("	for key := range raw {\n")
+	g.Printf("		if !%s(key).Valid() {\n", name)
+	g.Printf("			return fmt.Errorf(\"unknown key in %s: %%w\", %s)\n", enumMap, g.invalidError(name, "key"))
+	g.Printf("		}\n")
+	if g.open {
+		g.Printf("		if _, ok := m.index(%s(key)); !ok {\n", name)
+		g.Printf("			return fmt.Errorf(\"unknown key in %s: %%s is registered at runtime, and only declared values are keys\", key)\n", enumMap)
+		g.Printf("		}\n")
+	}
+	g.Printf("	}\n")
+	g.Printf("	var missing []string\n")
+	g.Printf("	var values [%s]V\n", count)
+	g.Printf("	for i, key := range %s {\n", g.ident("all-values", name))
+	g.Printf("		v, ok := raw[string(key)]\n")
+	g.Printf("		if !ok {\n")
+	g.Printf("			missing = append(missing, string(key))\n")
+	g.Printf("			continue\n")
+	g.Printf("		}\n")
+	g.Printf("		if err := json.Unmarshal(v, &values[i]); err != nil {\n")
+	g.Printf("			return fmt.Errorf(\"value of %%s in %s: %%w\", key, err)\n", enumMap)
+	g.Printf("		}\n")
+	g.Printf("	}\n")
+	g.Printf("	if len(missing) > 0 {\n")
+	g.Printf("		return fmt.Errorf(\"missing keys in %s: %%s\", strings.Join(missing, \", \"))\n", enumMap)
+	g.Printf("	}\n")
+	g.Printf("	m.values = values\n")
+	g.Printf("	return nil\n")
+	g.Printf("}\n")
+}
@@ -0,0 +1,46 @@
+package stringenumer
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (g *generator) buildEnvDecoding(name string) {
+	values := g.values[name]
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v.value)
+	}
+
+	g.Printf("\n// Decode takes an environment variable value, verifies that it is a correct %s and decodes it.\n", name)
+	g.Printf("// It implements the Decoder interface of envconfig\n")
+	g.Printf("func (v *%s) Decode(value string) error {\n", name)
+	g.Printf("	parsed, err := %s(value)\n", g.ident("parse", name))
+	g.Printf("	if err != nil {\n")
+	g.Printf("		return err\n")
+	g.Printf("	}\n")
+	g.Printf("	*v = parsed\n")
+	g.Printf("	return nil\n")
+	g.Printf("}\n\n")
+	g.Printf("// SetValue takes an environment variable value, verifies that it is a correct %s and sets it.\n", name)
+	g.Printf("// It implements the Setter interface of cleanenv\n")
+	g.Printf("func (v *%s) SetValue(value string) error {\n", name)
+	g.Printf("	return v.Decode(value)\n")
+	g.Printf("}\n\n")
+	g.Printf("// %s returns a description of the allowed %s values, to be used in documentation of environment variables\n", g.ident("env-help", name), name)
+	g.Printf("func %s() string {\n", g.ident("env-help", name))
+	if g.open {
+		// The registered values are only known at runtime
+		g.addImport(`"strconv"`)
+		g.addImport(`"strings"`)
+		g.Printf("	values := %s()\n", g.ident("values", name))
+		g.Printf("	quoted := make([]string, len(values))\n")
+		g.Printf("	for i, v := range values {\n")
+		g.Printf("		quoted[i] = strconv.Quote(string(v))\n")
+		g.Printf("	}\n")
+		g.Printf("	return \"allowed values: \" + strings.Join(quoted, \", \")\n")
+	} else {
+		g.Printf("	return %q\n", "allowed values: "+strings.Join(quoted, ", "))
+	}
+	g.Printf("}\n")
+}
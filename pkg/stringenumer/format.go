@@ -0,0 +1,53 @@
+package stringenumer
+
+func (g *generator) buildFormatting(name string) {
+	g.addImport(`"fmt"`)
+	g.addImport(`"strconv"`)
+	g.Printf("\n// GoString returns the constant name of a %s qualified by the package name,\n", name)
+	g.Printf("// or a conversion expression if the value is not valid\n")
+	g.Printf("func (v %s) GoString() string {\n", name)
+	g.Printf("	if name, ok := %s[v]; ok {\n", g.ident("go-names", name))
+	g.Printf("		return \"%s.\" + name\n", g.pkg.name)
+	g.Printf("	}\n")
+	g.Printf("	return fmt.Sprintf(\"%s.%s(%%q)\", string(v))\n", g.pkg.name, name)
+	g.Printf("}\n\n")
+	g.Printf("// Format implements fmt.Formatter. %%+v prints the constant name and %%#v the same as GoString,\n")
+	g.Printf("// all other verbs format the value as a string\n")
+	g.Printf("func (v %s) Format(f fmt.State, verb rune) {\n", name)
+	g.Printf("	var s string\n")
+	g.Printf("	switch {\n")
+	g.Printf("	case verb == 'v' && f.Flag('#'):\n")
+	g.Printf("		s = v.GoString()\n")
+	g.Printf("	case verb == 'v' && f.Flag('+'):\n")
+	g.Printf("		if name, ok := %s[v]; ok {\n", g.ident("go-names", name))
+	g.Printf("			s = name\n")
+	g.Printf("		} else {\n")
+	g.Printf("			s = fmt.Sprintf(\"%s(%%q)\", string(v))\n", name)
+	g.Printf("		}\n")
+	g.Printf("	default:\n")
+	g.Printf("		format := \"%%\"\n")
+	g.Printf("		for _, flag := range \"+-# 0\" {\n")
+	g.Printf("			if f.Flag(int(flag)) {\n")
+	g.Printf("				format += string(flag)\n")
+	g.Printf("			}\n")
+	g.Printf("		}\n")
+	g.Printf("		if width, ok := f.Width(); ok {\n")
+	g.Printf("			format += strconv.Itoa(width)\n")
+	g.Printf("		}\n")
+	g.Printf("		if precision, ok := f.Precision(); ok {\n")
+	g.Printf("			format += \".\" + strconv.Itoa(precision)\n")
+	g.Printf("		}\n")
+	g.Printf("		fmt.Fprintf(f, format+string(verb), string(v))\n")
+	g.Printf("		return\n")
+	g.Printf("	}\n")
+	g.Printf("	width, ok := f.Width()\n")
+	g.Printf("	switch {\n")
+	g.Printf("	case !ok:\n")
+	g.Printf("		fmt.Fprint(f, s)\n")
+	g.Printf("	case f.Flag('-'):\n")
+	g.Printf("		fmt.Fprintf(f, \"%%-*s\", width, s)\n")
+	g.Printf("	default:\n")
+	g.Printf("		fmt.Fprintf(f, \"%%*s\", width, s)\n")
+	g.Printf("	}\n")
+	g.Printf("}\n")
+}
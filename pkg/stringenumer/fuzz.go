@@ -0,0 +1,79 @@
+package stringenumer
+
+import (
+	"strings"
+)
+
+// fuzzSeeds returns the seed corpus of a type, all declared values and their case variants,
+// and the name and short name of every constant, which are likely to be mistaken for values
+func fuzzSeeds(typeName string, values []value) []string {
+	seen := map[string]struct{}{}
+	seeds := make([]string, 0, len(values)*5)
+	for _, v := range values {
+		for _, seed := range []string{v.value, strings.ToUpper(v.value), strings.ToLower(v.value), v.name, shortName(typeName, v)} {
+			if _, ok := seen[seed]; ok {
+				continue
+			}
+			seen[seed] = struct{}{}
+			seeds = append(seeds, seed)
+		}
+	}
+	return seeds
+}
+
+func (g *generator) printFuzzSeeds(name string) {
+	g.Printf("	for _, seed := range []string{\n")
+	for _, seed := range fuzzSeeds(name, g.values[name]) {
+		g.Printf("		%q,\n", seed)
+	}
+	g.Printf("	} {\n")
+	g.Printf("		f.Add(seed)\n")
+	g.Printf("	}\n")
+}
+
+func (g *generator) buildFuzzValid(name string) {
+	g.Printf("\n// %s verifies that Valid only accepts values returned by %s\n", g.ident("fuzz-valid", name), g.ident("values", name))
+	g.Printf("func %s(f *testing.F) {\n", g.ident("fuzz-valid", name))
+	g.printFuzzSeeds(name)
+	g.Printf("	f.Fuzz(func(t *testing.T, text string) {\n")
+	g.Printf("		if !%s(text).Valid() {\n", name)
+	g.Printf("			return\n")
+	g.Printf("		}\n")
+	g.Printf("		for _, v := range %s() {\n", g.ident("values", name))
+	g.Printf("			if v == %s(text) {\n", name)
+	g.Printf("				return\n")
+	g.Printf("			}\n")
+	g.Printf("		}\n")
+	g.Printf("		t.Fatalf(\"%%q is valid but not one of the %s values\", text)\n", name)
+	g.Printf("	})\n")
+	g.Printf("}\n")
+}
+
+func (g *generator) buildFuzzTextUnmarshaling(name string) {
+	g.addImport(`"encoding"`)
+	g.Printf("\n// %s verifies that UnmarshalText only accepts valid %s values, and that accepted values are marshaled\n", g.ident("fuzz-unmarshal-text", name), name)
+	g.Printf("// back to the same text, by MarshalText if %s has one\n", name)
+	g.Printf("func %s(f *testing.F) {\n", g.ident("fuzz-unmarshal-text", name))
+	g.printFuzzSeeds(name)
+	g.Printf("	f.Fuzz(func(t *testing.T, text string) {\n")
+	g.Printf("		var v %s\n", name)
+	g.Printf("		if err := v.UnmarshalText([]byte(text)); err != nil {\n")
+	g.Printf("			return\n")
+	g.Printf("		}\n")
+	g.Printf("		if !v.Valid() {\n")
+	g.Printf("			t.Fatalf(\"UnmarshalText accepted the invalid value %%q\", text)\n")
+	g.Printf("		}\n")
+	g.Printf("		marshaled := string(v)\n")
+	g.Printf("		if marshaler, ok := interface{}(v).(encoding.TextMarshaler); ok {\n")
+	g.Printf("			b, err := marshaler.MarshalText()\n")
+	g.Printf("			if err != nil {\n")
+	g.Printf("				t.Fatalf(\"could not marshal %%q: %%s\", text, err)\n")
+	g.Printf("			}\n")
+	g.Printf("			marshaled = string(b)\n")
+	g.Printf("		}\n")
+	g.Printf("		if marshaled != text {\n")
+	g.Printf("			t.Fatalf(\"%%q does not round-trip, it is marshaled as %%q\", text, marshaled)\n")
+	g.Printf("		}\n")
+	g.Printf("	})\n")
+	g.Printf("}\n")
+}
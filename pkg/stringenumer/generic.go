@@ -0,0 +1,8 @@
+package stringenumer
+
+func (g *generator) buildGeneric(name string) {
+	g.Printf("\n// AllValues returns a list of all (valid) %s values. It makes %s satisfy the enum.Enum interface\n", name, name)
+	g.Printf("func (%s) AllValues() []%s {\n", name, name)
+	g.Printf("	return %s()\n", g.ident("values", name))
+	g.Printf("}\n")
+}
@@ -0,0 +1,59 @@
+package stringenumer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultGoVersion is the Go version that generated code targets if it is neither set nor detected
+const defaultGoVersion = "1.18"
+
+// GoVersion sets the Go version, e.g. 1.21, that the generated code targets. Newer language and standard library
+// features are only used if the version allows it. By default, the version of the go directive of the module is used
+func GoVersion(version string) Option {
+	return func(g *generator) {
+		g.goVersion = version
+	}
+}
+
+// resolveGoVersion sets the Go version that the generated code targets, from the option or the module
+func (g *generator) resolveGoVersion() error {
+	version := g.goVersion
+	if version == "" {
+		version = g.pkg.goVersion
+	}
+	if version == "" {
+		version = defaultGoVersion
+	}
+
+	minor, err := parseGoVersion(version)
+	if err != nil {
+		return err
+	}
+	g.goMinor = minor
+	return nil
+}
+
+// parseGoVersion returns the minor version of a Go 1 version, e.g. 21 for 1.21, 1.21.3 or go1.21rc1
+func parseGoVersion(version string) (int, error) {
+	v := strings.TrimPrefix(version, "go")
+	if !strings.HasPrefix(v, "1.") {
+		return 0, fmt.Errorf("invalid Go version %q, expected e.g. 1.21", version)
+	}
+	v = v[len("1."):]
+	end := strings.IndexFunc(v, func(r rune) bool { return r < '0' || r > '9' })
+	if end >= 0 {
+		v = v[:end]
+	}
+	minor, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Go version %q, expected e.g. 1.21", version)
+	}
+	return minor, nil
+}
+
+// goAtLeast returns true if the generated code targets at least the Go version 1.minor
+func (g *generator) goAtLeast(minor int) bool {
+	return g.goMinor >= minor
+}
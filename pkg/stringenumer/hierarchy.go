@@ -0,0 +1,109 @@
+package stringenumer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parentValue returns the value of the parent of a hierarchical value, and false if it is a root value
+func parentValue(v, separator string) (string, bool) {
+	i := strings.LastIndex(v, separator)
+	if i < 0 {
+		return "", false
+	}
+	return v[:i], true
+}
+
+// validateHierarchy ensures that the parent of every hierarchical value exists among the values
+func validateHierarchy(typeName, separator string, values []value) error {
+	byValue := map[string]struct{}{}
+	for _, v := range values {
+		byValue[v.value] = struct{}{}
+	}
+	for _, v := range values {
+		for _, segment := range strings.Split(v.value, separator) {
+			if segment == "" {
+				return fmt.Errorf("%s has an empty segment in the hierarchical value %q", v.name, v.value)
+			}
+		}
+		parent, ok := parentValue(v.value, separator)
+		if !ok {
+			continue
+		}
+		if _, ok := byValue[parent]; !ok {
+			return fmt.Errorf("the parent %q of %s is not a value of %s", parent, v.name, typeName)
+		}
+	}
+	return nil
+}
+
+func (g *generator) buildHierarchy(name string) {
+	values := g.values[name]
+	byValue := map[string]value{}
+	for _, v := range values {
+		byValue[v.value] = v
+	}
+
+	// The constants of the children of each value, and of the values of each depth, in declaration order
+	children := map[string][]string{}
+	var byDepth [][]string
+	for _, v := range values {
+		if parent, ok := parentValue(v.value, g.hierarchySeparator); ok {
+			children[parent] = append(children[parent], v.name)
+		}
+		depth := strings.Count(v.value, g.hierarchySeparator)
+		for len(byDepth) <= depth {
+			byDepth = append(byDepth, nil)
+		}
+		byDepth[depth] = append(byDepth[depth], v.name)
+	}
+
+	g.Printf("\n// Parent returns the parent of a hierarchical %s, e.g. a for a%sb, and false if it has no parent\n", name, g.hierarchySeparator)
+	g.Printf("func (v %s) Parent() (%s, bool) {\n", name, name)
+	g.Printf("	switch v {\n")
+	for _, v := range values {
+		if parent, ok := parentValue(v.value, g.hierarchySeparator); ok {
+			g.Printf("	case %s:\n", v.name)
+			g.Printf("		return %s, true\n", byValue[parent].name)
+		}
+	}
+	g.Printf("	}\n")
+	g.Printf("	return \"\", false\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// Children returns the direct children of a hierarchical %s in declaration order\n", name)
+	g.Printf("func (v %s) Children() []%s {\n", name, name)
+	g.Printf("	switch v {\n")
+	for _, v := range values {
+		if len(children[v.value]) > 0 {
+			g.Printf("	case %s:\n", v.name)
+			g.Printf("		return []%s{%s}\n", name, strings.Join(children[v.value], ", "))
+		}
+	}
+	g.Printf("	}\n")
+	g.Printf("	return nil\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// IsDescendantOf returns true if the %s is a child, or a child of a child and so on, of the ancestor\n", name)
+	g.Printf("func (v %s) IsDescendantOf(ancestor %s) bool {\n", name, name)
+	g.Printf("	for p, ok := v.Parent(); ok; p, ok = p.Parent() {\n")
+	g.Printf("		if p == ancestor {\n")
+	g.Printf("			return true\n")
+	g.Printf("		}\n")
+	g.Printf("	}\n")
+	g.Printf("	return false\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// Depth returns the number of ancestors of a hierarchical %s, 0 for a root value, or -1 if it is not valid\n", name)
+	g.Printf("func (v %s) Depth() int {\n", name)
+	g.Printf("	switch v {\n")
+	for depth, names := range byDepth {
+		if len(names) > 0 {
+			g.Printf("	case %s:\n", strings.Join(names, ", "))
+			g.Printf("		return %d\n", depth)
+		}
+	}
+	g.Printf("	}\n")
+	g.Printf("	return -1\n")
+	g.Printf("}\n")
+}
@@ -0,0 +1,50 @@
+package stringenumer
+
+// label returns the label of a value, the description if it exists, otherwise the constant name
+func label(v value) string {
+	if v.description != "" {
+		return v.description
+	}
+	return v.name
+}
+
+func (g *generator) buildHTMLOptions(name string) {
+	g.Printf("\n// %s is an option of a HTML select element for a %s value\n", g.ident("option", name), name)
+	g.Printf("type %s struct {\n", g.ident("option", name))
+	g.Printf("	Value    string\n")
+	g.Printf("	Label    string\n")
+	g.Printf("	Selected bool\n")
+	g.Printf("}\n\n")
+	g.Printf("// %s returns the options of a HTML select element with all %s values in declaration order", g.ident("options", name), name)
+	if g.open {
+		g.Printf(",\n// followed by the registered values, labeled by their description if it exists")
+	}
+	g.Printf("\n")
+	g.Printf("func %s(selected %s) []%s {\n", g.ident("options", name), name, g.ident("option", name))
+	if !g.open {
+		g.Printf("	return []%s{\n", g.ident("option", name))
+	} else {
+		g.Printf("	options := []%s{\n", g.ident("option", name))
+	}
+	for _, v := range g.values[name] {
+		g.Printf("		{Value: %q, Label: %q, Selected: selected == %s},\n", v.value, label(v), v.name)
+	}
+	g.Printf("	}\n")
+	if g.open {
+		g.Printf("	%s.RLock()\n", g.ident("mutex", name))
+		g.Printf("	defer %s.RUnlock()\n", g.ident("mutex", name))
+		g.Printf("	for _, v := range %s {\n", g.ident("registered-values", name))
+		g.Printf("		label := %s[v].Description\n", g.ident("meta-map", name))
+		g.Printf("		if label == \"\" {\n")
+		g.Printf("			label = string(v)\n")
+		g.Printf("		}\n")
+		g.Printf("		options = append(options, %s{Value: string(v), Label: label, Selected: selected == v})\n", g.ident("option", name))
+		g.Printf("	}\n")
+		g.Printf("	return options\n")
+	}
+	g.Printf("}\n\n")
+	g.Printf("// %s takes a posted form value, verifies that it is a correct %s and returns it\n", g.ident("from-form-value", name), name)
+	g.Printf("func %s(value string) (%s, error) {\n", g.ident("from-form-value", name), name)
+	g.Printf("	return %s(value)\n", g.ident("parse", name))
+	g.Printf("}\n")
+}
@@ -0,0 +1,101 @@
+package stringenumer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// hasIDs returns true if any of the values has a numeric ID
+func hasIDs(values []value) bool {
+	for _, v := range values {
+		if _, ok := v.directives["id"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// validateIDs ensures that either no or all values have a numeric ID, and that no ID is used twice
+func validateIDs(typeName string, values []value) error {
+	if !hasIDs(values) {
+		return nil
+	}
+	ids := map[int]string{}
+	for _, v := range values {
+		if _, ok := v.directives["id"]; !ok {
+			return fmt.Errorf("the type %s has numeric IDs, but %s has no id set", typeName, v.name)
+		}
+		if other, ok := ids[v.id]; ok {
+			return fmt.Errorf("the type %s has multiple values with the id %d: %s and %s", typeName, v.id, other, v.name)
+		}
+		ids[v.id] = v.name
+	}
+	return nil
+}
+
+func (g *generator) buildIDs(name string) {
+	g.addImport(`"encoding/binary"`)
+	g.addImport(`"fmt"`)
+	values := g.values[name]
+
+	maxID := 0
+	maxIDLength := 0
+	for _, v := range values {
+		if v.id > maxID {
+			maxID = v.id
+		}
+		if l := len(strconv.Itoa(v.id)); l > maxIDLength {
+			maxIDLength = l
+		}
+	}
+
+	g.Printf("\n// %s contains the numeric ID of all valid %s values\n", g.ident("ids", name), name)
+	g.Printf("var %s = map[%s]int{\n", g.ident("ids", name), name)
+	maxNameLength := maxNameLength(values)
+	for _, v := range values {
+		g.Printf("	%s: %s%d,\n", v.name, strings.Repeat(" ", maxNameLength-utf8.RuneCountInString(v.name)), v.id)
+	}
+	g.Printf("}\n\n")
+	g.Printf("// %s contains all valid %s values by their numeric ID\n", g.ident("by-id", name), name)
+	g.Printf("var %s = map[int]%s{\n", g.ident("by-id", name), name)
+	for _, v := range values {
+		g.Printf("	%d: %s%s,\n", v.id, strings.Repeat(" ", maxIDLength-len(strconv.Itoa(v.id))), v.name)
+	}
+	g.Printf("}\n\n")
+	g.Printf("// ID returns the stable numeric ID of the %s, or -1 if the value is not valid\n", name)
+	g.Printf("func (v %s) ID() int {\n", name)
+	g.Printf("	if id, ok := %s[v]; ok {\n", g.ident("ids", name))
+	g.Printf("		return id\n")
+	g.Printf("	}\n")
+	g.Printf("	return -1\n")
+	g.Printf("}\n\n")
+	g.Printf("// %s returns the %s with the numeric ID\n", g.ident("from-id", name), name)
+	g.Printf("func %s(id int) (%s, bool) {\n", g.ident("from-id", name), name)
+	g.Printf("	v, ok := %s[id]\n", g.ident("by-id", name))
+	g.Printf("	return v, ok\n")
+	g.Printf("}\n\n")
+	g.Printf("// MarshalBinary verifies that the %s is correct and encodes its numeric ID as a varint\n", name)
+	g.Printf("func (v %s) MarshalBinary() ([]byte, error) {\n", name)
+	g.Printf("	id, ok := %s[v]\n", g.ident("ids", name))
+	g.Printf("	if !ok {\n")
+	g.Printf("		return nil, fmt.Errorf(\"not valid value for %s: %%s\", string(v))\n", name)
+	g.Printf("	}\n")
+	g.Printf("	buf := make([]byte, binary.MaxVarintLen64)\n")
+	g.Printf("	return buf[:binary.PutUvarint(buf, uint64(id))], nil\n")
+	g.Printf("}\n\n")
+	g.Printf("// UnmarshalBinary decodes a numeric ID encoded as a varint, verifies that it is the ID of a correct %s and unmarshals it\n", name)
+	g.Printf("func (v *%s) UnmarshalBinary(data []byte) error {\n", name)
+	g.Printf("	id, n := binary.Uvarint(data)\n")
+	g.Printf("	if n <= 0 || n != len(data) || id > %d {\n", maxID)
+	g.Printf("		return fmt.Errorf(\"not valid binary value for %s: %%x\", data)\n", name)
+	g.Printf("	}\n")
+	g.Printf("	parsed, ok := %s[int(id)]\n", g.ident("by-id", name))
+	g.Printf("	if !ok {\n")
+	g.Printf("		return fmt.Errorf(\"not valid id for %s: %%d\", id)\n", name)
+	g.Printf("	}\n")
+	g.Printf("	*v = parsed\n")
+	g.Printf("	return nil\n")
+	g.Printf("}\n")
+}
@@ -0,0 +1,192 @@
+package stringenumer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Orders of the values of generated list types
+const (
+	// ListOrderInput keeps the values of a list in the order they are unmarshaled in
+	ListOrderInput = "input"
+	// ListOrderDeclaration sorts the values of a list in the order their constants are declared in
+	ListOrderDeclaration = "declaration"
+)
+
+// validateListOptions verifies the options of the generated list types
+func (g *generator) validateListOptions() error {
+	if g.listSeparator == "" {
+		return fmt.Errorf("the list separator can not be empty")
+	}
+	if g.listOrder != ListOrderInput && g.listOrder != ListOrderDeclaration {
+		return fmt.Errorf("unknown list order %q, must be %q or %q", g.listOrder, ListOrderInput, ListOrderDeclaration)
+	}
+	return nil
+}
+
+// validateListValues verifies that no value contains the list separator,
+// since such a value would be split into several elements when unmarshaled
+func validateListValues(separator string, values []value) error {
+	for _, v := range values {
+		if strings.Contains(v.value, separator) {
+			return fmt.Errorf("%s contains the list separator %q in the value %q", v.name, separator, v.value)
+		}
+	}
+	return nil
+}
+
+func (g *generator) buildList(name string) {
+	g.addImport(`"database/sql/driver"`)
+	g.addImport(`"fmt"`)
+	g.addImport(`"strings"`)
+
+	list := g.ident("list", name)
+	g.Printf("\n// %s is a list of %s values, e.g. used for query parameters and Postgres arrays\n", list, name)
+	g.Printf("type %s []%s\n\n", list, name)
+
+	g.Printf("// setElements verifies that all elements are correct %s values, and sets the list to them", name)
+	if g.listDedupe {
+		g.Printf(" without duplicates")
+	}
+	if g.listOrder == ListOrderDeclaration {
+		g.Printf(" in declaration order")
+		if g.open {
+			g.Printf(", followed by the registered values in registration order")
+		}
+	}
+	g.Printf("\n")
+	g.Printf("func (l *%s) setElements(elements []string) error {\n", list)
+	g.Printf("	parsed := make(%s, 0, len(elements))\n", list)
+	if g.listDedupe {
+		g.Printf("	seen := make(map[%s]struct{}, len(elements))\n", name)
+	}
+	g.Printf("	for i, element := range elements {\n")
+	g.Printf("		v := %s(element)\n", name)
+	g.Printf("		if !v.Valid() {\n")
+	g.Printf("			return fmt.Errorf(\"invalid element at index %%d of %s: %%w\", i, %s)\n", list, g.invalidError(name, "element"))
+	g.Printf("		}\n")
+	if g.listDedupe {
+		g.Printf("		if _, ok := seen[v]; ok {\n")
+		g.Printf("			continue\n")
+		g.Printf("		}\n")
+		g.Printf("		seen[v] = struct{}{}\n")
+	}
+	g.Printf("		parsed = append(parsed, v)\n")
+	g.Printf("	}\n")
+	if g.listOrder == ListOrderDeclaration {
+		g.Printf("	counts := make(map[%s]int, len(parsed))\n", name)
+		g.Printf("	for _, v := range parsed {\n")
+		g.Printf("		counts[v]++\n")
+		g.Printf("	}\n")
+		g.Printf("	parsed = parsed[:0]\n")
+		g.Printf("	for _, v := range %s {\n", g.ident("all-values", name))
+		g.Printf("		for n := counts[v]; n > 0; n-- {\n")
+		g.Printf("			parsed = append(parsed, v)\n")
+		g.Printf("		}\n")
+		g.Printf("	}\n")
+		if g.open {
+			g.Printf("	%s.RLock()\n", g.ident("mutex", name))
+			g.Printf("	defer %s.RUnlock()\n", g.ident("mutex", name))
+			g.Printf("	for _, v := range %s {\n", g.ident("registered-values", name))
+			g.Printf("		for n := counts[v]; n > 0; n-- {\n")
+			g.Printf("			parsed = append(parsed, v)\n")
+			g.Printf("		}\n")
+			g.Printf("	}\n")
+		}
+	}
+	g.Printf("	*l = parsed\n")
+	g.Printf("	return nil\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// validate returns an error with the index of the first element that is not a valid %s\n", name)
+	g.Printf("func (l %s) validate() error {\n", list)
+	g.Printf("	for i, v := range l {\n")
+	g.Printf("		if !v.Valid() {\n")
+	g.Printf("			return fmt.Errorf(\"invalid element at index %%d of %s: %%w\", i, %s)\n", list, g.invalidError(name, "string(v)"))
+	g.Printf("		}\n")
+	g.Printf("	}\n")
+	g.Printf("	return nil\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// UnmarshalText takes a %q separated text, verifies that every element is a correct %s and unmarshals it\n", g.listSeparator, name)
+	g.Printf("func (l *%s) UnmarshalText(text []byte) error {\n", list)
+	g.Printf("	if len(text) == 0 {\n")
+	g.Printf("		*l = %s{}\n", list)
+	g.Printf("		return nil\n")
+	g.Printf("	}\n")
+	g.Printf("	return l.setElements(strings.Split(string(text), %q))\n", g.listSeparator)
+	g.Printf("}\n\n")
+
+	g.Printf("// MarshalText verifies that every element is a correct %s and marshals the list as a %q separated text\n", name, g.listSeparator)
+	g.Printf("func (l %s) MarshalText() ([]byte, error) {\n", list)
+	g.Printf("	if err := l.validate(); err != nil {\n")
+	g.Printf("		return nil, err\n")
+	g.Printf("	}\n")
+	g.Printf("	elements := make([]string, len(l))\n")
+	g.Printf("	for i, v := range l {\n")
+	g.Printf("		elements[i] = string(v)\n")
+	g.Printf("	}\n")
+	g.Printf("	return []byte(strings.Join(elements, %q)), nil\n", g.listSeparator)
+	g.Printf("}\n\n")
+
+	g.Printf("// Scan takes a Postgres array literal, e.g. {CA,SE}, verifies that every element is a correct %s and scans it.\n", name)
+	g.Printf("// It implements sql.Scanner\n")
+	g.Printf("func (l *%s) Scan(src interface{}) error {\n", list)
+	g.Printf("	var text string\n")
+	g.Printf("	switch src := src.(type) {\n")
+	g.Printf("	case string:\n")
+	g.Printf("		text = src\n")
+	g.Printf("	case []byte:\n")
+	g.Printf("		text = string(src)\n")
+	g.Printf("	case nil:\n")
+	g.Printf("		*l = nil\n")
+	g.Printf("		return nil\n")
+	g.Printf("	default:\n")
+	g.Printf("		return fmt.Errorf(\"can not scan %%T into %s\", src)\n", list)
+	g.Printf("	}\n")
+	g.Printf("	if len(text) < 2 || text[0] != '{' || text[len(text)-1] != '}' {\n")
+	g.Printf("		return fmt.Errorf(\"not a valid array literal for %s: %%s\", text)\n", list)
+	g.Printf("	}\n")
+	g.Printf("	if text == \"{}\" {\n")
+	g.Printf("		return l.setElements(nil)\n")
+	g.Printf("	}\n")
+	g.Printf("	var elements []string\n")
+	g.Printf("	var element strings.Builder\n")
+	g.Printf("	quoted, escaped := false, false\n")
+	g.Printf("	for _, r := range text[1 : len(text)-1] {\n")
+	g.Printf("		switch {\n")
+	g.Printf("		case escaped:\n")
+	g.Printf("			element.WriteRune(r)\n")
+	g.Printf("			escaped = false\n")
+	g.Printf("		case r == '\\\\':\n")
+	g.Printf("			escaped = true\n")
+	g.Printf("		case r == '\"':\n")
+	g.Printf("			quoted = !quoted\n")
+	g.Printf("		case r == ',' && !quoted:\n")
+	g.Printf("			elements = append(elements, element.String())\n")
+	g.Printf("			element.Reset()\n")
+	g.Printf("		default:\n")
+	g.Printf("			element.WriteRune(r)\n")
+	g.Printf("		}\n")
+	g.Printf("	}\n")
+	g.Printf("	elements = append(elements, element.String())\n")
+	g.Printf("	return l.setElements(elements)\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// Value verifies that every element is a correct %s and returns the list as a Postgres array literal.\n", name)
+	g.Printf("// It implements driver.Valuer\n")
+	g.Printf("func (l %s) Value() (driver.Value, error) {\n", list)
+	g.Printf("	if l == nil {\n")
+	g.Printf("		return nil, nil\n")
+	g.Printf("	}\n")
+	g.Printf("	if err := l.validate(); err != nil {\n")
+	g.Printf("		return nil, err\n")
+	g.Printf("	}\n")
+	g.Printf("	escaper := strings.NewReplacer(`\\`, `\\\\`, `\"`, `\\\"`)\n")
+	g.Printf("	elements := make([]string, len(l))\n")
+	g.Printf("	for i, v := range l {\n")
+	g.Printf("		elements[i] = `\"` + escaper.Replace(string(v)) + `\"`\n")
+	g.Printf("	}\n")
+	g.Printf("	return \"{\" + strings.Join(elements, \",\") + \"}\", nil\n")
+	g.Printf("}\n")
+}
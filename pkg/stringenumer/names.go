@@ -0,0 +1,112 @@
+package stringenumer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// shortName returns the name of the constant with the type name prefix removed. The prefix is only removed
+// at a word boundary, where it is followed by an upper case letter, a digit or an underscore, e.g. TestA and Test_A
+// are A, but Testing stays Testing
+func shortName(typeName string, v value) string {
+	if !strings.HasPrefix(v.name, typeName) {
+		return v.name
+	}
+	short := v.name[len(typeName):]
+	r, _ := utf8.DecodeRuneInString(short)
+	if !unicode.IsUpper(r) && !unicode.IsDigit(r) && r != '_' {
+		return v.name
+	}
+	if short = strings.TrimPrefix(short, "_"); short == "" {
+		return v.name
+	}
+	return short
+}
+
+// validateShortNames ensures that every name and short name identifies a single constant
+func validateShortNames(typeName string, values []value) error {
+	names := map[string]string{}
+	for _, v := range values {
+		names[v.name] = v.name
+	}
+	for _, v := range values {
+		short := shortName(typeName, v)
+		if other, ok := names[short]; ok && other != v.name {
+			return fmt.Errorf("the type %s has multiple constants with the name %s: %s and %s", typeName, short, other, v.name)
+		}
+		names[short] = v.name
+	}
+	return nil
+}
+
+// buildGoNames builds a map from each value to the name of its constant
+func (g *generator) buildGoNames(name string) {
+	values := g.values[name]
+	g.Printf("\n// %s contains the constant name of all valid %s values\n", g.ident("go-names", name), name)
+	g.Printf("var %s = map[%s]string{\n", g.ident("go-names", name), name)
+	maxNameLength := maxNameLength(values)
+	for _, v := range values {
+		g.Printf("	%s: %s%q,\n", v.name, strings.Repeat(" ", maxNameLength-utf8.RuneCountInString(v.name)), v.name)
+	}
+	g.Printf("}\n")
+}
+
+func (g *generator) buildNames(name string) {
+	values := g.values[name]
+	maxNameLength := maxNameLength(values)
+
+	g.Printf("\n// %s contains the constant name without the %s prefix of all valid %s values\n", g.ident("short-names", name), name, name)
+	g.Printf("var %s = map[%s]string{\n", g.ident("short-names", name), name)
+	for _, v := range values {
+		g.Printf("	%s: %s%q,\n", v.name, strings.Repeat(" ", maxNameLength-utf8.RuneCountInString(v.name)), shortName(name, v))
+	}
+	g.Printf("}\n\n")
+
+	byName := map[string]string{}
+	names := make([]string, 0, len(values)*2)
+	for _, v := range values {
+		for _, n := range []string{v.name, shortName(name, v)} {
+			if _, ok := byName[n]; !ok {
+				names = append(names, n)
+			}
+			byName[n] = v.name
+		}
+	}
+	maxLength := 0
+	for _, n := range names {
+		if l := utf8.RuneCountInString(fmt.Sprintf("%q", n)); l > maxLength {
+			maxLength = l
+		}
+	}
+	g.Printf("// %s contains all valid %s values by their constant name, with and without the %s prefix\n", g.ident("by-name", name), name, name)
+	g.Printf("var %s = map[string]%s{\n", g.ident("by-name", name), name)
+	for _, n := range names {
+		quoted := fmt.Sprintf("%q", n)
+		g.Printf("	%s: %s%s,\n", quoted, strings.Repeat(" ", maxLength-utf8.RuneCountInString(quoted)), byName[n])
+	}
+	g.Printf("}\n\n")
+
+	g.Printf("// Name returns the constant name of the %s, or an empty string if the value is not valid\n", name)
+	g.Printf("func (v %s) Name() string {\n", name)
+	g.Printf("	return %s[v]\n", g.ident("go-names", name))
+	g.Printf("}\n\n")
+	g.Printf("// ShortName returns the constant name without the %s prefix, or an empty string if the value is not valid\n", name)
+	g.Printf("func (v %s) ShortName() string {\n", name)
+	g.Printf("	return %s[v]\n", g.ident("short-names", name))
+	g.Printf("}\n\n")
+	g.Printf("// %s returns the %s with the constant name, with or without the %s prefix\n", g.ident("from-name", name), name, name)
+	g.Printf("func %s(name string) (%s, bool) {\n", g.ident("from-name", name), name)
+	g.Printf("	v, ok := %s[name]\n", g.ident("by-name", name))
+	g.Printf("	return v, ok\n")
+	g.Printf("}\n\n")
+	g.Printf("// %s returns a list of the constant names of all (valid) %s values\n", g.ident("names", name), name)
+	g.Printf("func %s() []string {\n", g.ident("names", name))
+	g.Printf("	return []string{\n")
+	for _, v := range values {
+		g.Printf("		%q,\n", v.name)
+	}
+	g.Printf("	}\n")
+	g.Printf("}\n")
+}
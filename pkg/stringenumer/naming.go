@@ -0,0 +1,200 @@
+package stringenumer
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultNameTemplates contains the naming templates of all generated package level identifiers, by their kind.
+// Identifiers of unexported types are unexported by default, to not leak into the API of the package
+var defaultNameTemplates = map[string]string{
+	"values-map":          "valid{{upperFirst .Name}}Values",
+	"values":              "{{.Name}}Values",
+	"count":               "{{.Name}}Count",
+	"all-values":          "all{{upperFirst .Name}}Values",
+	"values-array":        "{{.Name}}ValuesArray",
+	"value-at":            "{{.Name}}ValueAt",
+	"values-seq":          "{{.Name}}ValuesSeq",
+	"parse":               `{{prefix "Parse" .Name}}`,
+	"invalid-error":       `{{prefix "Invalid" .Name}}Error`,
+	"suggest":             "suggest{{upperFirst .Name}}Values",
+	"list":                "{{.Name}}List",
+	"map":                 "{{.Name}}Map",
+	"new-map":             `{{prefix "New" .Name}}Map`,
+	"env-help":            "{{.Name}}EnvHelp",
+	"option":              "{{.Name}}Option",
+	"options":             "{{.Name}}Options",
+	"from-form-value":     "{{.Name}}FromFormValue",
+	"completions":         "{{.Name}}Completions",
+	"go-names":            "goNames{{upperFirst .Name}}",
+	"short-names":         "shortNames{{upperFirst .Name}}",
+	"by-name":             "byName{{upperFirst .Name}}",
+	"from-name":           "{{.Name}}FromName",
+	"names":               "{{.Name}}Names",
+	"ids":                 "ids{{upperFirst .Name}}",
+	"by-id":               "byID{{upperFirst .Name}}",
+	"from-id":             "{{.Name}}FromID",
+	"from-proto":          "{{.Name}}FromProto",
+	"parse-api-version":   "parse{{upperFirst .Name}}APIVersion",
+	"values-for":          "{{.Name}}ValuesFor",
+	"mutex":               "mutex{{upperFirst .Name}}",
+	"registered-values":   "registered{{upperFirst .Name}}Values",
+	"meta-map":            "meta{{upperFirst .Name}}",
+	"meta":                "{{.Name}}Meta",
+	"register":            `{{prefix "Register" .Name}}`,
+	"fuzz-valid":          "Fuzz{{upperFirst .Name}}Valid",
+	"fuzz-unmarshal-text": "Fuzz{{upperFirst .Name}}UnmarshalText",
+}
+
+// nameFuncs are the functions available in naming templates
+var nameFuncs = template.FuncMap{
+	"upperFirst": upperFirst,
+	"lowerFirst": lowerFirst,
+	"prefix":     prefixName,
+}
+
+// NameTemplates overrides the naming templates of generated identifiers. The key of the map is the kind of identifier,
+// e.g. values or parse, and the value is a text/template executed with the name of the type as .Name
+func NameTemplates(templates map[string]string) Option {
+	return func(g *generator) {
+		g.nameTemplates = templates
+	}
+}
+
+// NameKinds returns the kinds of all generated identifiers that the naming templates can be set for, sorted
+func NameKinds() []string {
+	kinds := make([]string, 0, len(defaultNameTemplates))
+	for kind := range defaultNameTemplates {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// generates returns true if an identifier of the kind is generated for the type with the current options.
+// Fuzz tests are written separately, and are always considered to be generated
+func (g *generator) generates(kind, typeName string) bool {
+	switch kind {
+	case "values-seq":
+		return g.goAtLeast(23)
+	case "parse":
+		return g.unmarshalText || g.envDecoding || g.htmlOptions || g.orm
+	case "invalid-error":
+		return g.generates("parse", typeName) || g.validate || g.list || g.enumMap || hasAPIVersions(g.values[typeName])
+	case "suggest":
+		return g.generates("invalid-error", typeName) && len(g.values[typeName]) <= g.suggestionThreshold
+	case "list":
+		return g.list
+	case "map", "new-map":
+		return g.enumMap
+	case "env-help":
+		return g.envDecoding
+	case "option", "options", "from-form-value":
+		return g.htmlOptions
+	case "completions":
+		return g.completions
+	case "go-names":
+		return g.formatting || g.names
+	case "short-names", "by-name", "from-name", "names":
+		return g.names
+	case "ids", "by-id", "from-id":
+		return hasIDs(g.values[typeName])
+	case "mutex", "registered-values", "meta-map", "meta", "register":
+		return g.open
+	case "parse-api-version", "values-for":
+		return hasAPIVersions(g.values[typeName])
+	case "from-proto":
+		_, ok := g.protoTypes[typeName]
+		return ok
+	}
+	return true
+}
+
+// resolveNames executes the naming templates for all types, and verifies that all generated identifiers are valid and unique
+func (g *generator) resolveNames() error {
+	for kind := range g.nameTemplates {
+		if _, ok := defaultNameTemplates[kind]; !ok {
+			return fmt.Errorf("unknown kind of identifier %q, must be one of %s", kind, strings.Join(NameKinds(), ", "))
+		}
+	}
+
+	templates := map[string]*template.Template{}
+	for _, kind := range NameKinds() {
+		text := defaultNameTemplates[kind]
+		if override, ok := g.nameTemplates[kind]; ok {
+			text = override
+		}
+		tmpl, err := template.New(kind).Funcs(nameFuncs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return fmt.Errorf("invalid naming template of %s: %w", kind, err)
+		}
+		templates[kind] = tmpl
+	}
+
+	var errors multiError
+	kindOf := map[string]string{} // The kind and type of each identifier, to find collisions
+	g.identifiers = map[string]map[string]string{}
+	for _, typeName := range g.typenames() {
+		g.identifiers[typeName] = map[string]string{}
+		for _, kind := range NameKinds() {
+			var buf bytes.Buffer
+			if err := templates[kind].Execute(&buf, struct{ Name string }{typeName}); err != nil {
+				errors = append(errors, fmt.Errorf("could not execute the naming template of %s: %w", kind, err))
+				continue
+			}
+			ident := buf.String()
+			g.identifiers[typeName][kind] = ident
+			if !g.generates(kind, typeName) {
+				continue
+			}
+			if !token.IsIdentifier(ident) {
+				errors = append(errors, fmt.Errorf("the %s name of %s is not a valid identifier: %q", kind, typeName, ident))
+				continue
+			}
+			if other, ok := kindOf[ident]; ok {
+				errors = append(errors, fmt.Errorf("the %s name of %s collides with the %s: %s", kind, typeName, other, ident))
+				continue
+			}
+			kindOf[ident] = fmt.Sprintf("%s name of %s", kind, typeName)
+		}
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// ident returns the name of a generated identifier of a type
+func (g *generator) ident(kind, typeName string) string {
+	ident, ok := g.identifiers[typeName][kind]
+	if !ok {
+		panic(fmt.Sprintf("no %s identifier resolved for %s", kind, typeName))
+	}
+	return ident
+}
+
+// upperFirst returns the name with the first letter in upper case
+func upperFirst(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+// lowerFirst returns the name with the first letter in lower case
+func lowerFirst(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+// prefixName prefixes a name, and makes the result exported only if the name is, e.g. Parse and country is parseCountry
+func prefixName(prefix, name string) string {
+	if token.IsExported(name) {
+		return upperFirst(prefix) + upperFirst(name)
+	}
+	return lowerFirst(prefix) + upperFirst(name)
+}
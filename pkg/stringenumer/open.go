@@ -0,0 +1,66 @@
+package stringenumer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+func (g *generator) buildOpenRegistration(name string) {
+	g.addImport(`"errors"`)
+	g.addImport(`"fmt"`)
+	g.addImport(`"sync"`)
+
+	mutex := g.ident("mutex", name)
+	meta := g.ident("meta", name)
+	metaMap := g.ident("meta-map", name)
+	registered := g.ident("registered-values", name)
+
+	g.Printf("\n// %s guards the %s values registered at runtime\n", mutex, name)
+	g.Printf("var %s sync.RWMutex\n\n", mutex)
+	g.Printf("// %s contains the %s values registered at runtime, in registration order\n", registered, name)
+	g.Printf("var %s []%s\n\n", registered, name)
+
+	g.Printf("// %s is the metadata of a %s value\n", meta, name)
+	g.Printf("type %s struct {\n", meta)
+	g.Printf("	Description string\n")
+	g.Printf("}\n\n")
+
+	values := g.values[name]
+	g.Printf("// %s contains the metadata of all valid %s values, the declared and the registered\n", metaMap, name)
+	g.Printf("var %s = map[%s]%s{\n", metaMap, name, meta)
+	maxNameLength := maxNameLength(values)
+	for _, v := range values {
+		padding := strings.Repeat(" ", maxNameLength-utf8.RuneCountInString(v.name))
+		if v.description == "" {
+			g.Printf("	%s: %s{},\n", v.name, padding)
+		} else {
+			g.Printf("	%s: %s{Description: %q},\n", v.name, padding, v.description)
+		}
+	}
+	g.Printf("}\n\n")
+
+	g.Printf("// Meta returns the metadata of the value, and false if it is not a valid %s\n", name)
+	g.Printf("func (v %s) Meta() (%s, bool) {\n", name, meta)
+	g.Printf("	%s.RLock()\n", mutex)
+	g.Printf("	defer %s.RUnlock()\n", mutex)
+	g.Printf("	m, ok := %s[v]\n", metaMap)
+	g.Printf("	return m, ok\n")
+	g.Printf("}\n\n")
+
+	g.Printf("// %s registers a value that is valid in addition to the declared %s constants, e.g. from configuration.\n", g.ident("register", name), name)
+	g.Printf("// It returns an error if the value is empty or already valid. It is safe to use concurrently\n")
+	g.Printf("func %s(v %s, meta %s) error {\n", g.ident("register", name), name, meta)
+	g.Printf("	if v == \"\" {\n")
+	g.Printf("		return errors.New(\"can not register an empty %s value\")\n", name)
+	g.Printf("	}\n")
+	g.Printf("	%s.Lock()\n", mutex)
+	g.Printf("	defer %s.Unlock()\n", mutex)
+	g.Printf("	if _, ok := %s[v]; ok {\n", g.ident("values-map", name))
+	g.Printf("		return fmt.Errorf(\"the %s value %%s is already valid\", string(v))\n", name)
+	g.Printf("	}\n")
+	g.Printf("	%s[v] = struct{}{}\n", g.ident("values-map", name))
+	g.Printf("	%s[v] = meta\n", metaMap)
+	g.Printf("	%s = append(%s, v)\n", registered, registered)
+	g.Printf("	return nil\n")
+	g.Printf("}\n")
+}
@@ -0,0 +1,43 @@
+package stringenumer
+
+func (g *generator) buildORM(name string) {
+	g.addImport(`"database/sql/driver"`)
+	g.addImport(`"fmt"`)
+	g.Printf("\n// Values returns a list of all (valid) %s values as strings. It implements the EnumValues interface of ent\n", name)
+	g.Printf("func (%s) Values() []string {\n", name)
+	g.Printf("	return []string{\n")
+	for _, v := range g.values[name] {
+		g.Printf("		%q,\n", v.value)
+	}
+	g.Printf("	}\n")
+	g.Printf("}\n\n")
+	g.Printf("// GormDataType returns the data type used for %s by GORM\n", name)
+	g.Printf("func (%s) GormDataType() string {\n", name)
+	g.Printf("	return \"string\"\n")
+	g.Printf("}\n\n")
+	g.Printf("// Scan takes a database value, verifies that it is a correct %s and scans it. It implements sql.Scanner\n", name)
+	g.Printf("func (v *%s) Scan(src interface{}) error {\n", name)
+	g.Printf("	var text string\n")
+	g.Printf("	switch src := src.(type) {\n")
+	g.Printf("	case string:\n")
+	g.Printf("		text = src\n")
+	g.Printf("	case []byte:\n")
+	g.Printf("		text = string(src)\n")
+	g.Printf("	default:\n")
+	g.Printf("		return fmt.Errorf(\"can not scan %%T into %s\", src)\n", name)
+	g.Printf("	}\n")
+	g.Printf("	parsed, err := %s(text)\n", g.ident("parse", name))
+	g.Printf("	if err != nil {\n")
+	g.Printf("		return err\n")
+	g.Printf("	}\n")
+	g.Printf("	*v = parsed\n")
+	g.Printf("	return nil\n")
+	g.Printf("}\n\n")
+	g.Printf("// Value verifies that the %s is correct and returns it as a database value. It implements driver.Valuer\n", name)
+	g.Printf("func (v %s) Value() (driver.Value, error) {\n", name)
+	g.Printf("	if !v.Valid() {\n")
+	g.Printf("		return nil, fmt.Errorf(\"not valid value for %s: %%s\", string(v))\n", name)
+	g.Printf("	}\n")
+	g.Printf("	return string(v), nil\n")
+	g.Printf("}\n")
+}
@@ -0,0 +1,214 @@
+package stringenumer
+
+import (
+	"fmt"
+	"go/constant"
+	"go/types"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Rules of how values are matched with the values of protobuf enums
+const (
+	// ProtoMatchName matches the constant name, without the type prefix, with the protobuf value name
+	ProtoMatchName = "name"
+	// ProtoMatchValue matches the string value with the protobuf value name
+	ProtoMatchValue = "value"
+)
+
+// protoEnum is a protobuf-generated enum type that a type is converted to and from
+type protoEnum struct {
+	pkgName string
+	pkgPath string
+	name    string
+	// The names of the protobuf constants by the name of the constant of the type they match
+	matches map[string]string
+}
+
+// protoConstant is a constant of a protobuf-generated enum type
+type protoConstant struct {
+	name   string
+	number int64
+}
+
+// loadProtoEnums loads the protobuf enum types set for each type, and matches their values
+func (g *generator) loadProtoEnums() error {
+	if g.protoMatching != ProtoMatchName && g.protoMatching != ProtoMatchValue {
+		return fmt.Errorf("unknown protobuf matching rule %q, must be %q or %q", g.protoMatching, ProtoMatchName, ProtoMatchValue)
+	}
+
+	var errors multiError
+	for _, typeName := range g.typenames() {
+		qualified, ok := g.protoTypes[typeName]
+		if !ok {
+			continue
+		}
+		enum, err := g.loadProtoEnum(typeName, qualified)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		g.protoEnums[typeName] = enum
+	}
+	for typeName := range g.protoTypes {
+		if _, ok := g.values[typeName]; !ok {
+			errors = append(errors, fmt.Errorf("protobuf enum set for %s, which is not a type with any values", typeName))
+		}
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// loadProtoEnum loads a protobuf enum type, defined as import/path.Type, and matches its values with the values of a type
+func (g *generator) loadProtoEnum(typeName, qualified string) (*protoEnum, error) {
+	dot := strings.LastIndex(qualified, ".")
+	if dot <= 0 || strings.LastIndex(qualified, "/") > dot {
+		return nil, fmt.Errorf("protobuf enum of %s must be set as import/path.Type, got %q", typeName, qualified)
+	}
+	pkgPath, protoName := qualified[:dot], qualified[dot+1:]
+
+	// The import path is resolved in the module of the package, not of the working directory
+	cfg := &packages.Config{Mode: packages.NeedName | packages.NeedTypes, Dir: g.pkg.dir()}
+	pkgs, err := packages.Load(cfg, pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 || pkgs[0].Types == nil || len(pkgs[0].Errors) > 0 {
+		return nil, fmt.Errorf("could not load the protobuf package %s", pkgPath)
+	}
+	scope := pkgs[0].Types.Scope()
+
+	typeObj, ok := scope.Lookup(protoName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("could not find the protobuf enum %s", qualified)
+	}
+	if basic, ok := typeObj.Type().Underlying().(*types.Basic); !ok || basic.Info()&types.IsInteger == 0 {
+		return nil, fmt.Errorf("the protobuf enum %s is not an integer type", qualified)
+	}
+
+	var constants []protoConstant
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), typeObj.Type()) {
+			continue
+		}
+		number, _ := constant.Int64Val(c.Val())
+		constants = append(constants, protoConstant{name: name, number: number})
+	}
+	sort.SliceStable(constants, func(i, j int) bool {
+		return constants[i].number < constants[j].number
+	})
+
+	// The protobuf value names without the Go type and the enum name prefix, e.g. Country_COUNTRY_CANADA -> CANADA
+	enumPrefix := upperSnakeCase(protoName) + "_"
+	byMatchName := map[string]string{}
+	unmatched := map[string]struct{}{}
+	for _, c := range constants {
+		matchName := strings.TrimPrefix(c.name, protoName+"_")
+		matchName = upperSnakeCase(strings.TrimPrefix(matchName, enumPrefix))
+		if c.number == 0 && strings.HasSuffix(matchName, "UNSPECIFIED") {
+			// The default value of protobuf enums has no matching value
+			continue
+		}
+		if other, ok := byMatchName[matchName]; ok {
+			return nil, fmt.Errorf("the protobuf enum %s has multiple values matching %s: %s and %s", qualified, matchName, other, c.name)
+		}
+		byMatchName[matchName] = c.name
+		unmatched[c.name] = struct{}{}
+	}
+
+	var errors multiError
+	matches := map[string]string{}
+	for _, v := range g.values[typeName] {
+		matchName := upperSnakeCase(shortName(typeName, v))
+		if g.protoMatching == ProtoMatchValue {
+			matchName = upperSnakeCase(v.value)
+		}
+		protoConst, ok := byMatchName[matchName]
+		if !ok {
+			errors = append(errors, fmt.Errorf("%s has no matching value in the protobuf enum %s", v.name, qualified))
+			continue
+		}
+		if _, ok := unmatched[protoConst]; !ok {
+			errors = append(errors, fmt.Errorf("%s matches the protobuf value %s, which is already matched", v.name, protoConst))
+			continue
+		}
+		delete(unmatched, protoConst)
+		matches[v.name] = protoConst
+	}
+	for _, c := range constants {
+		if _, ok := unmatched[c.name]; ok {
+			errors = append(errors, fmt.Errorf("the protobuf value %s has no matching value in %s", c.name, typeName))
+		}
+	}
+	if len(errors) > 0 {
+		return nil, errors
+	}
+
+	return &protoEnum{
+		pkgName: pkgs[0].Name,
+		pkgPath: pkgPath,
+		name:    protoName,
+		matches: matches,
+	}, nil
+}
+
+// upperSnakeCase converts a name or value to the UPPER_SNAKE_CASE used for protobuf enum values
+func upperSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			r = '_'
+		}
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteRune('_')
+			}
+		}
+		if r == '_' && strings.HasSuffix(b.String(), "_") {
+			continue
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return strings.Trim(b.String(), "_")
+}
+
+func (g *generator) buildProtoConversion(name string) {
+	enum := g.protoEnums[name]
+	if path.Base(enum.pkgPath) == enum.pkgName {
+		g.addImport(fmt.Sprintf("%q", enum.pkgPath))
+	} else {
+		g.addImport(fmt.Sprintf("%s %q", enum.pkgName, enum.pkgPath))
+	}
+	protoType := enum.pkgName + "." + enum.name
+
+	g.Printf("\n// ToProto converts the %s to its protobuf representation, or the zero value if the %s is not valid\n", name, name)
+	g.Printf("func (v %s) ToProto() %s {\n", name, protoType)
+	g.Printf("	switch v {\n")
+	for _, v := range g.values[name] {
+		g.Printf("	case %s:\n", v.name)
+		g.Printf("		return %s.%s\n", enum.pkgName, enum.matches[v.name])
+	}
+	g.Printf("	}\n")
+	g.Printf("	return 0\n")
+	g.Printf("}\n\n")
+	g.Printf("// %s converts a protobuf representation to a %s, and returns false if it has no %s value\n", g.ident("from-proto", name), name, name)
+	g.Printf("func %s(p %s) (%s, bool) {\n", g.ident("from-proto", name), protoType, name)
+	g.Printf("	switch p {\n")
+	for _, v := range g.values[name] {
+		g.Printf("	case %s.%s:\n", enum.pkgName, enum.matches[v.name])
+		g.Printf("		return %s, true\n", v.name)
+	}
+	g.Printf("	}\n")
+	g.Printf("	return \"\", false\n")
+	g.Printf("}\n")
+}
@@ -0,0 +1,23 @@
+package stringenumer
+
+// registryPath is the import path of the package that the types are registered in
+const registryPath = "github.com/lindell/string-enumer/pkg/registry"
+
+func (g *generator) buildRegistration(name string) {
+	g.addImport(`"` + registryPath + `"`)
+	g.Printf("\n// init registers %s in the registry of enum types\n", name)
+	g.Printf("func init() {\n")
+	g.Printf("	registry.Register(registry.Enum{\n")
+	g.Printf("		Name:    %q,\n", name)
+	g.Printf("		PkgPath: %q,\n", g.pkg.path)
+	g.Printf("		Values: []registry.Value{\n")
+	for _, v := range g.values[name] {
+		g.Printf("			{Name: %q, Value: %q, Description: %q},\n", v.name, v.value, v.description)
+	}
+	g.Printf("		},\n")
+	g.Printf("		Valid: func(value string) bool {\n")
+	g.Printf("			return %s(value).Valid()\n", name)
+	g.Printf("		},\n")
+	g.Printf("	})\n")
+	g.Printf("}\n")
+}
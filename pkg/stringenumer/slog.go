@@ -0,0 +1,36 @@
+package stringenumer
+
+// redactedLogValue replaces invalid values in logs when log redaction is enabled
+const redactedLogValue = "[redacted]"
+
+func (g *generator) buildLogValue(name string) {
+	g.addImport(`"log/slog"`)
+
+	g.Printf("\n// LogValue returns a group with the value, the constant name and if the value is valid, to be used by log/slog.\n")
+	if g.open {
+		g.Printf("// Registered values have no constant name.\n")
+	}
+	if g.logRedaction {
+		g.Printf("// The value is redacted if it is not a valid %s\n", name)
+	} else {
+		g.Printf("// It makes invalid %s values distinguishable in logs\n", name)
+	}
+	g.Printf("func (v %s) LogValue() slog.Value {\n", name)
+	g.Printf("	switch v {\n")
+	for _, v := range g.values[name] {
+		g.Printf("	case %s:\n", v.name)
+		g.Printf("		return slog.GroupValue(slog.String(\"value\", string(v)), slog.String(\"name\", %q), slog.Bool(\"valid\", true))\n", v.name)
+	}
+	g.Printf("	}\n")
+	if g.open {
+		g.Printf("	if v.Valid() {\n")
+		g.Printf("		return slog.GroupValue(slog.String(\"value\", string(v)), slog.Bool(\"valid\", true))\n")
+		g.Printf("	}\n")
+	}
+	if g.logRedaction {
+		g.Printf("	return slog.GroupValue(slog.String(\"value\", %q), slog.Bool(\"valid\", false))\n", redactedLogValue)
+	} else {
+		g.Printf("	return slog.GroupValue(slog.String(\"value\", string(v)), slog.Bool(\"valid\", false))\n")
+	}
+	g.Printf("}\n")
+}
@@ -14,6 +14,7 @@ package stringenumer
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/constant"
@@ -21,7 +22,9 @@ import (
 	"go/types"
 	"io"
 	"log"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -46,6 +49,167 @@ func TextUnmarshaling(unmarshalText bool) Option {
 	}
 }
 
+// EnvDecoding sets if decoding methods used by environment variable libraries should be generated or not
+func EnvDecoding(envDecoding bool) Option {
+	return func(g *generator) {
+		g.envDecoding = envDecoding
+	}
+}
+
+// HTMLOptions sets if helpers for HTML select elements and form decoding should be generated or not
+func HTMLOptions(htmlOptions bool) Option {
+	return func(g *generator) {
+		g.htmlOptions = htmlOptions
+	}
+}
+
+// Completions sets if shell completion functions should be generated or not
+func Completions(completions bool) Option {
+	return func(g *generator) {
+		g.completions = completions
+	}
+}
+
+// Registry sets if the types should be registered in the registry package or not
+func Registry(registry bool) Option {
+	return func(g *generator) {
+		g.registry = registry
+	}
+}
+
+// Generic sets if methods needed to satisfy the interface of the enum package should be generated or not
+func Generic(generic bool) Option {
+	return func(g *generator) {
+		g.generic = generic
+	}
+}
+
+// ORM sets if methods used by ent, GORM and database/sql should be generated or not
+func ORM(orm bool) Option {
+	return func(g *generator) {
+		g.orm = orm
+	}
+}
+
+// Formatting sets if GoString and Format methods should be generated or not
+func Formatting(formatting bool) Option {
+	return func(g *generator) {
+		g.formatting = formatting
+	}
+}
+
+// Names sets if functions to get and parse values by their constant names should be generated or not
+func Names(names bool) Option {
+	return func(g *generator) {
+		g.names = names
+	}
+}
+
+// Validate sets if a Validate method, returning a typed error for invalid values, should be generated or not
+func Validate(validate bool) Option {
+	return func(g *generator) {
+		g.validate = validate
+	}
+}
+
+// LogValue sets if LogValue methods, logging values as a group with the value, the constant name and if it is valid,
+// should be generated or not. It requires log/slog, so the targeted Go version must be 1.21 or later
+func LogValue(logValue bool) Option {
+	return func(g *generator) {
+		g.logValue = logValue
+	}
+}
+
+// LogRedaction sets if invalid values should be redacted by the generated LogValue methods
+func LogRedaction(logRedaction bool) Option {
+	return func(g *generator) {
+		g.logRedaction = logRedaction
+	}
+}
+
+// List sets if a list type, with text and Postgres array marshaling, should be generated or not
+func List(list bool) Option {
+	return func(g *generator) {
+		g.list = list
+	}
+}
+
+// ListSeparator sets the separator of the values of list types in text, "," by default
+func ListSeparator(separator string) Option {
+	return func(g *generator) {
+		g.listSeparator = separator
+	}
+}
+
+// ListDedupe sets if duplicate values should be removed when list types are unmarshaled or not
+func ListDedupe(dedupe bool) Option {
+	return func(g *generator) {
+		g.listDedupe = dedupe
+	}
+}
+
+// ListOrder sets the order of the values of unmarshaled list types, ListOrderInput or ListOrderDeclaration
+func ListOrder(order string) Option {
+	return func(g *generator) {
+		g.listOrder = order
+	}
+}
+
+// Map sets if a generic map type, with a value for every value of the type, should be generated or not
+func Map(enumMap bool) Option {
+	return func(g *generator) {
+		g.enumMap = enumMap
+	}
+}
+
+// Hierarchy sets the separator of hierarchical values, e.g. "." for "billing.invoice".
+// If set, every parent must be a value of the type, and helpers to navigate the hierarchy are generated
+func Hierarchy(separator string) Option {
+	return func(g *generator) {
+		g.hierarchySeparator = separator
+	}
+}
+
+// Open sets if values can be registered at runtime, in addition to the declared constants, or not.
+// The generated lookups are then guarded by a mutex
+func Open(open bool) Option {
+	return func(g *generator) {
+		g.open = open
+	}
+}
+
+// SuggestionThreshold sets the maximum number of values of a type for which invalid errors suggest the closest values,
+// DefaultSuggestionThreshold by default. Suggestions are not generated at all if it is 0
+func SuggestionThreshold(max int) Option {
+	return func(g *generator) {
+		g.suggestionThreshold = max
+	}
+}
+
+// ProtoEnums sets the protobuf-generated enum types, defined as import/path.Type, that types should be
+// converted to and from. The key of the map is the name of the type
+func ProtoEnums(protoTypes map[string]string) Option {
+	return func(g *generator) {
+		g.protoTypes = protoTypes
+	}
+}
+
+// ProtoMatching sets the rule of how values are matched with protobuf enum values, ProtoMatchName or ProtoMatchValue.
+// Generation fails if any value on either side is not matched
+func ProtoMatching(rule string) Option {
+	return func(g *generator) {
+		g.protoMatching = rule
+	}
+}
+
+// StructValidation sets if Validate methods should be generated for all struct types in the package
+// that contain fields of the types
+func StructValidation(structValidation bool) Option {
+	return func(g *generator) {
+		g.structValidation = structValidation
+	}
+}
+
 // Paths sets the paths from where code should be read from
 func Paths(paths ...string) Option {
 	return func(g *generator) {
@@ -65,31 +229,130 @@ func (m multiError) Error() string {
 
 // Generate returns a reader with generated code
 func Generate(options ...Option) (io.Reader, error) {
-	g := generator{
-		values:  map[string][]value{},
-		imports: map[string]struct{}{},
+	g, err := newGenerator(options...)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, option := range options {
-		option(&g)
+	if g.logValue && !g.goAtLeast(21) {
+		return nil, errors.New("LogValue methods use log/slog, which needs Go 1.21 or later")
 	}
 
-	g.parsePackage(g.paths...)
-	g.parseFiles()
+	if g.logRedaction && !g.logValue {
+		return nil, errors.New("log redaction requires LogValue methods to be generated")
+	}
 
-	if len(g.errors) != 0 {
-		return nil, g.errors
+	if g.enumMap && !g.goAtLeast(18) {
+		return nil, errors.New("map types use generics, which needs Go 1.18 or later")
 	}
 
-	if err := g.validateValues(); err != nil {
-		return nil, err
+	if g.list {
+		if err := g.validateListOptions(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(g.protoTypes) > 0 {
+		if err := g.loadProtoEnums(); err != nil {
+			return nil, err
+		}
 	}
 
 	for _, typename := range g.typenames() {
 		g.buildBasics(typename)
+		if g.generates("invalid-error", typename) {
+			g.buildInvalidError(typename)
+		}
+		if g.generates("suggest", typename) {
+			g.buildSuggestions(typename)
+		}
+		if g.generates("parse", typename) {
+			g.buildParse(typename)
+		}
+		if g.validate {
+			g.buildValidate(typename)
+		}
 		if g.unmarshalText {
 			g.buildTextUnmarshaling(typename)
 		}
+		if g.envDecoding {
+			g.buildEnvDecoding(typename)
+		}
+		if g.htmlOptions {
+			g.buildHTMLOptions(typename)
+		}
+		if g.completions {
+			g.buildCompletions(typename)
+		}
+		if g.registry {
+			g.buildRegistration(typename)
+		}
+		if g.generic {
+			g.buildGeneric(typename)
+		}
+		if g.orm {
+			g.buildORM(typename)
+		}
+		if g.formatting || g.names {
+			g.buildGoNames(typename)
+		}
+		if g.formatting {
+			g.buildFormatting(typename)
+		}
+		if g.names {
+			g.buildNames(typename)
+		}
+		if hasIDs(g.values[typename]) {
+			g.buildIDs(typename)
+		}
+		if hasAPIVersions(g.values[typename]) {
+			g.buildAPIVersions(typename)
+		}
+		if g.logValue {
+			g.buildLogValue(typename)
+		}
+		if g.open {
+			g.buildOpenRegistration(typename)
+		}
+		if g.list {
+			g.buildList(typename)
+		}
+		if g.enumMap {
+			g.buildMap(typename)
+		}
+		if g.hierarchySeparator != "" {
+			g.buildHierarchy(typename)
+		}
+		if _, ok := g.protoEnums[typename]; ok {
+			g.buildProtoConversion(typename)
+		}
+	}
+
+	if g.structValidation {
+		for _, s := range g.enumStructs() {
+			g.buildStructValidation(s)
+		}
+	}
+
+	g.buildHeader()
+
+	return io.MultiReader(&g.headerBuf, &g.buf), nil
+}
+
+// GenerateFuzzTests returns a reader with generated fuzz tests for the code returned by Generate.
+// The output is meant to be written to a _test.go file in the same package
+func GenerateFuzzTests(options ...Option) (io.Reader, error) {
+	g, err := newGenerator(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	g.addImport(`"testing"`)
+	for _, typename := range g.typenames() {
+		g.buildFuzzValid(typename)
+		if g.unmarshalText {
+			g.buildFuzzTextUnmarshaling(typename)
+		}
 	}
 
 	g.buildHeader()
@@ -97,23 +360,72 @@ func Generate(options ...Option) (io.Reader, error) {
 	return io.MultiReader(&g.headerBuf, &g.buf), nil
 }
 
+// newGenerator creates a generator with all options applied, and with the values of the package parsed and validated
+func newGenerator(options ...Option) (*generator, error) {
+	g := &generator{
+		values:        map[string][]value{},
+		imports:       map[string]struct{}{},
+		expanding:     map[types.Type]struct{}{},
+		protoMatching: ProtoMatchName,
+		listSeparator: ",",
+		listOrder:     ListOrderInput,
+		protoEnums:    map[string]*protoEnum{},
+
+		suggestionThreshold: DefaultSuggestionThreshold,
+	}
+
+	for _, option := range options {
+		option(g)
+	}
+
+	g.parsePackage(g.paths...)
+	g.parseFiles()
+
+	if len(g.errors) != 0 {
+		return nil, g.errors
+	}
+
+	if err := g.validateValues(); err != nil {
+		return nil, err
+	}
+
+	if err := g.resolveGoVersion(); err != nil {
+		return nil, err
+	}
+
+	if err := g.resolveNames(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
 // file holds a single parsed file and associated data.
 type file struct {
 	pkg  *pkg      // Package to which this file belongs.
 	file *ast.File // Parsed AST.
+	path string    // Path of the source file.
 }
 
 // value represents a declared constant.
 type value struct {
-	name  string
-	value string
+	name        string
+	value       string
+	description string // The first line of the line comment, or the doc comment, of the constant
+	directives  map[string]string
+	id          int // The numeric ID of the value, only set if the id directive is set
 }
 
 // pkg holds information about a Go package
 type pkg struct {
 	name  string
+	path  string
+	fset  *token.FileSet
+	types *types.Package
 	defs  map[*ast.Ident]types.Object
 	files []*file
+
+	goVersion string // The version of the go directive of the module, if any
 }
 
 type generator struct {
@@ -127,6 +439,43 @@ type generator struct {
 	values map[string][]value
 
 	unmarshalText bool
+	envDecoding   bool
+	htmlOptions   bool
+	completions   bool
+	registry      bool
+	generic       bool
+	orm           bool
+	formatting    bool
+	names         bool
+	validate      bool
+	logValue      bool
+	logRedaction  bool
+
+	list          bool
+	listSeparator string
+	listDedupe    bool
+	listOrder     string
+
+	enumMap bool
+
+	hierarchySeparator string
+
+	open bool
+
+	suggestionThreshold int // The maximum number of values of a type for which suggestions are generated
+
+	protoTypes    map[string]string // The protobuf enum, as import/path.Type, by the name of the type
+	protoMatching string
+	protoEnums    map[string]*protoEnum
+
+	structValidation bool
+	expanding        map[types.Type]struct{} // Named types currently being expanded while generating struct validation
+
+	goVersion string // The Go version set as an option
+	goMinor   int    // The minor version of Go 1 that the generated code targets
+
+	nameTemplates map[string]string            // Overridden naming templates by the kind of identifier
+	identifiers   map[string]map[string]string // Generated identifiers by the name of the type and the kind of identifier
 
 	imports   map[string]struct{}
 	headerBuf bytes.Buffer
@@ -141,7 +490,7 @@ func (g *generator) Printf(format string, args ...interface{}) {
 // parsePackage exits if there is an error.
 func (g *generator) parsePackage(patterns ...string) {
 	cfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax,
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax | packages.NeedModule,
 	}
 	pkgs, err := packages.Load(cfg, patterns...)
 	if err != nil {
@@ -167,18 +516,33 @@ func (g *generator) typenames() []string {
 func (g *generator) addPackage(p *packages.Package) {
 	g.pkg = &pkg{
 		name:  p.Name,
+		path:  p.PkgPath,
+		fset:  p.Fset,
+		types: p.Types,
 		defs:  p.TypesInfo.Defs,
 		files: make([]*file, len(p.Syntax)),
 	}
+	if p.Module != nil {
+		g.pkg.goVersion = p.Module.GoVersion
+	}
 
 	for i, f := range p.Syntax {
 		g.pkg.files[i] = &file{
 			file: f,
 			pkg:  g.pkg,
+			path: p.Fset.File(f.Pos()).Name(),
 		}
 	}
 }
 
+// dir returns the directory of the package
+func (p *pkg) dir() string {
+	if len(p.files) == 0 {
+		return ""
+	}
+	return filepath.Dir(p.files[0].path)
+}
+
 func (g *generator) isTypeName(tn string) bool {
 	for _, typeName := range g.typeNames {
 		if typeName == tn {
@@ -263,9 +627,24 @@ func (g *generator) genDecl(f *file) func(node ast.Node) bool {
 				}
 				str := constant.StringVal(val)
 
+				comments := constComments(decl, vspec)
+				directives, err := parseDirectives(comments)
+				if err != nil {
+					g.errors = append(g.errors, fmt.Errorf("%s: %s", name, err))
+					return false
+				}
+
 				v := value{
-					name:  name.Name,
-					value: str,
+					name:        name.Name,
+					value:       str,
+					description: description(comments),
+					directives:  directives,
+				}
+				if id, ok := directives["id"]; ok {
+					if v.id, err = strconv.Atoi(id); err != nil || v.id < 0 {
+						g.errors = append(g.errors, fmt.Errorf("%s: the id must be a non-negative integer, got %q", name, id))
+						return false
+					}
 				}
 				g.values[typ] = append(g.values[typ], v)
 			}
@@ -274,7 +653,9 @@ func (g *generator) genDecl(f *file) func(node ast.Node) bool {
 	}
 }
 
-// validateValues ensures that there exist no more than one value of each type
+// validateValues ensures that there exist no more than one value of each type,
+// that the short names of the constants are unique if names are generated,
+// and that the numeric IDs are unique and set on all values if set on any
 func (g *generator) validateValues() error {
 	var errors multiError
 	for typeName, v := range g.values {
@@ -286,6 +667,27 @@ func (g *generator) validateValues() error {
 			}
 			values[value.value] = struct{}{}
 		}
+		if g.names {
+			if err := validateShortNames(typeName, v); err != nil {
+				errors = append(errors, err)
+			}
+		}
+		if err := validateIDs(typeName, v); err != nil {
+			errors = append(errors, err)
+		}
+		if err := validateAPIVersions(v); err != nil {
+			errors = append(errors, err)
+		}
+		if g.list {
+			if err := validateListValues(g.listSeparator, v); err != nil {
+				errors = append(errors, err)
+			}
+		}
+		if g.hierarchySeparator != "" {
+			if err := validateHierarchy(typeName, g.hierarchySeparator, v); err != nil {
+				errors = append(errors, err)
+			}
+		}
 	}
 	if len(errors) > 0 {
 		return errors
@@ -299,11 +701,14 @@ func (g *generator) buildHeader() {
 	if len(g.imports) > 0 {
 		fmt.Fprintln(&g.headerBuf, "\nimport (")
 
-		// Sort imports by name
+		// Sort imports by path
 		imports := make([]string, 0, len(g.imports))
 		for imp := range g.imports {
 			imports = append(imports, imp)
 		}
+		sort.Slice(imports, func(i, j int) bool {
+			return importPath(imports[i]) < importPath(imports[j])
+		})
 
 		for _, imp := range imports {
 			fmt.Fprintln(&g.headerBuf, "	"+imp)
@@ -312,38 +717,106 @@ func (g *generator) buildHeader() {
 	}
 }
 
+// importPath returns the quoted path of an import line, that can have a package name
+func importPath(imp string) string {
+	return imp[strings.Index(imp, `"`):]
+}
+
 func (g *generator) buildBasics(name string) {
 	values := g.values[name]
-	g.Printf("\n// valid%sValues contains a map of all valid %s values for easy lookup\n", strings.Title(name), name)
-	g.Printf("var valid%sValues = map[%s]struct{}{\n", strings.Title(name), name)
+	g.Printf("\n// %s contains a map of all valid %s values for easy lookup\n", g.ident("values-map", name), name)
+	g.Printf("var %s = map[%s]struct{}{\n", g.ident("values-map", name), name)
 	maxNameLength := maxNameLength(values)
 	for _, v := range values {
 		g.Printf("	%s: %s{},\n", v.name, strings.Repeat(" ", maxNameLength-utf8.RuneCountInString(v.name)))
 	}
 	g.Printf("}\n\n")
-	g.Printf("// Valid validates if a value is a valid %s\n", name)
+	g.Printf("// Valid validates if a value is a valid %s, one of the values listed by %s\n", name, g.ident("values", name))
 	g.Printf("func (v %s) Valid() bool {\n", name)
-	g.Printf("	_, ok := valid%sValues[v]\n", strings.Title(name))
+	if g.open {
+		g.Printf("	%s.RLock()\n", g.ident("mutex", name))
+		g.Printf("	defer %s.RUnlock()\n", g.ident("mutex", name))
+	}
+	g.Printf("	_, ok := %s[v]\n", g.ident("values-map", name))
 	g.Printf("	return ok\n")
 	g.Printf("}\n\n")
-	g.Printf("// %sValues returns a list of all (valid) %s values\n", strings.Title(name), name)
-	g.Printf("func %sValues() []%s {\n", strings.Title(name), name)
-	g.Printf("	return []%s{\n", name)
+	if g.open {
+		g.Printf("// %s returns a list of all (valid) %s values, followed by the registered values in registration order:\n", g.ident("values", name), name)
+	} else {
+		g.Printf("// %s returns a list of all (valid) %s values:\n", g.ident("values", name), name)
+	}
+	g.printValueList(name)
+	g.Printf("func %s() []%s {\n", g.ident("values", name), name)
+	if g.open {
+		g.Printf("	%s.RLock()\n", g.ident("mutex", name))
+		g.Printf("	defer %s.RUnlock()\n", g.ident("mutex", name))
+		g.Printf("	values := make([]%s, 0, len(%s))\n", name, g.ident("values-map", name))
+		g.Printf("	values = append(values, %s[:]...)\n", g.ident("all-values", name))
+		g.Printf("	return append(values, %s...)\n", g.ident("registered-values", name))
+	} else if g.goAtLeast(21) {
+		g.addImport(`"slices"`)
+		g.Printf("	return slices.Clone(%s[:])\n", g.ident("all-values", name))
+	} else {
+		g.Printf("	return []%s{\n", name)
+		for _, v := range values {
+			g.Printf("		%s,\n", v.name)
+		}
+		g.Printf("	}\n")
+	}
+	g.Printf("}\n\n")
+	if g.open {
+		g.Printf("// %s is the number of declared %s values. Registered values are not counted, nor part of the arrays below\n", g.ident("count", name), name)
+	} else {
+		g.Printf("// %s is the number of (valid) %s values\n", g.ident("count", name), name)
+	}
+	g.Printf("const %s = %d\n\n", g.ident("count", name), len(values))
+	g.Printf("// %s contains all valid %s values in declaration order\n", g.ident("all-values", name), name)
+	g.Printf("var %s = [%s]%s{\n", g.ident("all-values", name), g.ident("count", name), name)
 	for _, v := range values {
-		g.Printf("		%s,\n", v.name)
+		g.Printf("	%s,\n", v.name)
+	}
+	g.Printf("}\n\n")
+	g.Printf("// %s returns an array of all (valid) %s values, without any heap allocation\n", g.ident("values-array", name), name)
+	g.Printf("func %s() [%s]%s {\n", g.ident("values-array", name), g.ident("count", name), name)
+	g.Printf("	return %s\n", g.ident("all-values", name))
+	g.Printf("}\n\n")
+	g.Printf("// %s returns the i:th %s value in declaration order. It panics if i is not in the range [0, %s)\n", g.ident("value-at", name), name, g.ident("count", name))
+	g.Printf("func %s(i int) %s {\n", g.ident("value-at", name), name)
+	g.Printf("	return %s[i]\n", g.ident("all-values", name))
+	g.Printf("}\n")
+	if g.goAtLeast(23) {
+		g.addImport(`"iter"`)
+		g.Printf("\n// %s returns an iterator over all (valid) %s values in declaration order\n", g.ident("values-seq", name), name)
+		g.Printf("func %s() iter.Seq[%s] {\n", g.ident("values-seq", name), name)
+		g.Printf("	return func(yield func(%s) bool) {\n", name)
+		g.Printf("		for _, v := range %s {\n", g.ident("all-values", name))
+		g.Printf("			if !yield(v) {\n")
+		g.Printf("				return\n")
+		g.Printf("			}\n")
+		g.Printf("		}\n")
+		g.Printf("	}\n")
+		g.Printf("}\n")
 	}
+}
+
+func (g *generator) buildParse(name string) {
+	g.Printf("\n// %s takes a text, verifies that it is a correct %s and returns it. The valid values are listed by %s\n", g.ident("parse", name), name, g.ident("values", name))
+	g.Printf("func %s(text string) (%s, error) {\n", g.ident("parse", name), name)
+	g.Printf("	if valid := %s(text).Valid(); !valid {\n", name)
+	g.Printf("		return \"\", %s\n", g.invalidError(name, "text"))
 	g.Printf("	}\n")
+	g.Printf("	return %s(text), nil\n", name)
 	g.Printf("}\n")
 }
 
 func (g *generator) buildTextUnmarshaling(name string) {
-	g.addImport(`"fmt"`)
-	g.Printf("\n// UnmarshalText takes a text, verifies that it is a correct %s and unmarshals it\n", name)
-	g.Printf("func (v *%s) UnmarshalText(text []byte) error {\n", strings.Title(name))
-	g.Printf("	if valid := %s(text).Valid(); !valid {\n", name)
-	g.Printf("		return fmt.Errorf(\"not valid value for %s: %%s\", text)\n", name)
+	g.Printf("\n// UnmarshalText takes a text, verifies that it is a correct %s and unmarshals it. The valid values are listed by %s\n", name, g.ident("values", name))
+	g.Printf("func (v *%s) UnmarshalText(text []byte) error {\n", name)
+	g.Printf("	parsed, err := %s(string(text))\n", g.ident("parse", name))
+	g.Printf("	if err != nil {\n")
+	g.Printf("		return err\n")
 	g.Printf("	}\n")
-	g.Printf("	*v = %s(text)\n", name)
+	g.Printf("	*v = parsed\n")
 	g.Printf("	return nil\n")
 	g.Printf("}\n")
 }
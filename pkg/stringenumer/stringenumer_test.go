@@ -2,7 +2,14 @@ package stringenumer
 
 import (
 	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -33,3 +40,548 @@ func TestPurity(t *testing.T) {
 		}
 	}
 }
+
+func TestShortNameCollision(t *testing.T) {
+	_, err := Generate(
+		Paths("testdata/shortnames.go"),
+		TypeNames("Test"),
+		Names(true),
+	)
+	if err == nil {
+		t.Fatal("expected colliding short names to result in an error")
+	}
+
+	_, err = Generate(
+		Paths("testdata/shortnames.go"),
+		TypeNames("Test"),
+	)
+	if err != nil {
+		t.Fatalf("short names should not be validated if names are not generated: %s", err)
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "string-enumer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	src, err := ioutil.ReadFile("testdata/annotate.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(tmpDir, "annotate.go")
+	if err := ioutil.WriteFile(path, src, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	options := []Option{
+		Paths(path),
+		TypeNames("Test", "Test2", "Test3", "Test4"),
+	}
+
+	outdated, err := CheckAnnotations(options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outdated) != 1 || outdated[0] != path {
+		t.Fatalf("expected %s to be outdated, got %v", path, outdated)
+	}
+
+	changed, err := Annotate(options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 1 || changed[0] != path {
+		t.Fatalf("expected %s to be changed, got %v", path, changed)
+	}
+
+	annotated, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"// Test is a test type\n// +kubebuilder:validation:Enum=test;hello\ntype Test string\n",
+		"\t// Other is not annotated\n\tOther string\n",
+		"\t// +kubebuilder:validation:Enum=test\n\tTest2 string\n",
+		"// Test3 is annotated without a space after the comment prefix\n//+kubebuilder:validation:Enum=test\ntype Test3 string\n",
+		"// +kubebuilder:validation:Enum=\"a b\";\"c;d\";e\ntype Test4 string\n",
+	} {
+		if !strings.Contains(string(annotated), expected) {
+			t.Errorf("annotated file does not contain %q:\n%s", expected, annotated)
+		}
+	}
+
+	// Annotating again should not change anything
+	changed, err = Annotate(options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 0 {
+		t.Fatalf("expected no changes when annotating again, got %v", changed)
+	}
+	outdated, err = CheckAnnotations(options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outdated) != 0 {
+		t.Fatalf("expected no outdated files, got %v", outdated)
+	}
+}
+
+func TestDocument(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "string-enumer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	src, err := ioutil.ReadFile("testdata/annotate.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(tmpDir, "annotate.go")
+	if err := ioutil.WriteFile(path, src, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	options := []Option{
+		Paths(path),
+		TypeNames("Test", "Test2"),
+	}
+
+	outdated, err := CheckDocs(options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outdated) != 1 || outdated[0] != path {
+		t.Fatalf("expected %s to be outdated, got %v", path, outdated)
+	}
+
+	if _, err := Document(options...); err != nil {
+		t.Fatal(err)
+	}
+	documented, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"// Test is a test type\n//\n// Valid Test values:\n//   - TestTest (\"test\")\n//   - TestTest2 (\"hello\")\n//\n// +kubebuilder:validation:Enum=test\ntype Test string\n",
+		"\t// Valid Test2 values:\n\t//   - Test2Test (\"test\")\n\tTest2 string\n",
+	} {
+		if !strings.Contains(string(documented), expected) {
+			t.Errorf("documented file does not contain %q:\n%s", expected, documented)
+		}
+	}
+
+	// A changed value should update the existing list
+	changedSrc := strings.Replace(string(documented), `TestTest2 Test = "hello"`, `TestTest2 Test = "hi" // Greeting`, 1)
+	if err := ioutil.WriteFile(path, []byte(changedSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Document(options...); err != nil {
+		t.Fatal(err)
+	}
+	documented, err = ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "// Valid Test values:\n//   - TestTest (\"test\")\n//   - TestTest2 (\"hi\"): Greeting\n//\n// +kubebuilder"
+	if !strings.Contains(string(documented), expected) {
+		t.Errorf("documented file does not contain %q:\n%s", expected, documented)
+	}
+
+	outdated, err = CheckDocs(options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outdated) != 0 {
+		t.Fatalf("expected no outdated files, got %v", outdated)
+	}
+}
+
+func TestProtoMatching(t *testing.T) {
+	for _, rule := range []string{ProtoMatchName, ProtoMatchValue} {
+		r, err := Generate(
+			Paths("testdata/month.go"),
+			TypeNames("Month"),
+			ProtoEnums(map[string]string{"Month": "time.Month"}),
+			ProtoMatching(rule),
+		)
+		if err != nil {
+			t.Fatalf("could not match with the %s rule: %s", rule, err)
+		}
+		code, _ := ioutil.ReadAll(r)
+		if !strings.Contains(string(code), "case MonthMarch:\n\t\treturn time.March\n") {
+			t.Fatalf("wrong conversion with the %s rule:\n%s", rule, code)
+		}
+	}
+
+	_, err := Generate(
+		Paths("testdata/month.go"),
+		TypeNames("SomeMonth"),
+		ProtoEnums(map[string]string{"SomeMonth": "time.Month"}),
+	)
+	if err == nil {
+		t.Fatal("expected unmatched values to result in an error")
+	}
+	for _, expected := range []string{
+		"SomeMonthSmarch has no matching value",
+		"the protobuf value February has no matching value",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q: %s", expected, err)
+		}
+	}
+}
+
+func TestProtoOtherModule(t *testing.T) {
+	// The protobuf package is in the module of the package, not in the module of the working directory
+	r, err := Generate(
+		Paths("../../testdata/proto/main.go"),
+		TypeNames("Country"),
+		ProtoEnums(map[string]string{"Country": "example.com/proto/pb.Country"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, _ := ioutil.ReadAll(r)
+	if !strings.Contains(string(code), "func CountryFromProto(") {
+		t.Errorf("expected a conversion from the protobuf enum:\n%s", code)
+	}
+}
+
+func TestShortName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"TestA":      "A",
+		"TestCanada": "Canada",
+		"Test2":      "2",
+		"Test_A":     "A",
+		"Testing":    "Testing",
+		"Test":       "Test",
+		"Test_":      "Test_",
+		"Other":      "Other",
+	} {
+		if got := shortName("Test", value{name: name}); got != expected {
+			t.Errorf("shortName(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
+
+func TestUpperSnakeCase(t *testing.T) {
+	for in, expected := range map[string]string{
+		"Canada":        "CANADA",
+		"UnitedStates":  "UNITED_STATES",
+		"HTTPServer":    "HTTP_SERVER",
+		"united-states": "UNITED_STATES",
+		"CA":            "CA",
+		"v2Beta":        "V2_BETA",
+	} {
+		if got := upperSnakeCase(in); got != expected {
+			t.Errorf("upperSnakeCase(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
+
+func TestIDValidation(t *testing.T) {
+	for typeName, expected := range map[string]string{
+		"Incomplete": "the type Incomplete has numeric IDs, but IncompleteB has no id set",
+		"Duplicate":  "the type Duplicate has multiple values with the id 1: DuplicateA and DuplicateB",
+		"Malformed":  `MalformedA: the id must be a non-negative integer, got "one"`,
+		"Unknown":    `UnknownA: unknown directive "identifier"`,
+	} {
+		_, err := Generate(
+			Paths("testdata/ids.go"),
+			TypeNames(typeName),
+		)
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected the error %q for %s, got: %v", expected, typeName, err)
+		}
+	}
+}
+
+func TestDirectives(t *testing.T) {
+	comments := []*ast.CommentGroup{
+		{List: []*ast.Comment{{Text: "// enum: legacy value"}}},
+		{List: []*ast.Comment{{Text: "// enum:id=7"}, {Text: "// enum:identifier is not a directive"}}},
+	}
+	directives, err := parseDirectives(comments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(directives) != 1 || directives["id"] != "7" {
+		t.Errorf("expected only the id directive, got %v", directives)
+	}
+	if d := description(comments); d != "enum: legacy value" {
+		t.Errorf("expected the description %q, got %q", "enum: legacy value", d)
+	}
+}
+
+func TestAllowedValues(t *testing.T) {
+	for expected, values := range map[string][]string{
+		` (allowed values: "a", "", "b, c")`:                                                  {"a", "", "b, c"},
+		` (allowed values: "0", "1", "2", "3", "4", "5", "6", "7", "8", "9", ... and 2 more)`: {"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"},
+	} {
+		if allowed := allowedValues(values); allowed != expected {
+			t.Errorf("expected %q, got %q", expected, allowed)
+		}
+	}
+}
+
+func TestOwnStructValidation(t *testing.T) {
+	// The package contains the output of an earlier run, whose methods must not count as written by hand
+	r, err := Generate(
+		Paths("./testdata/ownvalidate"),
+		TypeNames("Test"),
+		StructValidation(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, _ := ioutil.ReadAll(r)
+	if strings.Contains(string(code), "func (s Request)") || strings.Contains(string(code), "func (s Pointer)") {
+		t.Errorf("expected no methods to be generated for a struct with its own Validate method:\n%s", code)
+	}
+	for _, expected := range []string{
+		"func (s Outer) Validate() error {",
+		"if err := s.Request.Validate(); err != nil {",
+		"if err := s.Pointer.Validate(); err != nil {",
+		"if err := v0.Validate(); err != nil {",
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Errorf("expected the code to contain %q:\n%s", expected, code)
+		}
+	}
+}
+
+func TestUnexportedNames(t *testing.T) {
+	r, err := Generate(
+		Paths("../../testdata/unexported.go"),
+		TypeNames("country"),
+		TextUnmarshaling(true),
+		EnvDecoding(true),
+		HTMLOptions(true),
+		Completions(true),
+		ORM(true),
+		Formatting(true),
+		Names(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, _ := ioutil.ReadAll(r)
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", code, 0)
+	if err != nil {
+		t.Fatalf("could not parse generated code: %s\n%s", err, code)
+	}
+	for name, obj := range file.Scope.Objects {
+		if token.IsExported(name) {
+			t.Errorf("the %s %s of an unexported type is exported", obj.Kind, name)
+		}
+	}
+}
+
+func TestNameTemplates(t *testing.T) {
+	for templates, expected := range map[string]string{
+		"unknown=x":             `unknown kind of identifier "unknown"`,
+		"values={{.Name":        "invalid naming template of values",
+		"values={{.Name}}-List": `the values name of Test is not a valid identifier: "Test-List"`,
+		"values={{.Name}}Count": "the values name of Test collides with the count name of Test: TestCount",
+	} {
+		kindAndTemplate := strings.SplitN(templates, "=", 2)
+		_, err := Generate(
+			Paths("../../testdata/names.go"),
+			TypeNames("Test"),
+			NameTemplates(map[string]string{kindAndTemplate[0]: kindAndTemplate[1]}),
+		)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected the error %q for %s, got: %v", expected, templates, err)
+		}
+	}
+}
+
+func TestGoVersion(t *testing.T) {
+	for version, expected := range map[string][]string{
+		"1.18":    {"return []Test{\n"},
+		"1.20":    {"return []Test{\n", "errors.Join(errs...)"},
+		"1.21.3":  {"slices.Clone(allTestValues[:])", "errors.Join(errs...)"},
+		"go1.23":  {"slices.Clone(allTestValues[:])", "func TestValuesSeq() iter.Seq[Test] {"},
+		"1.24rc1": {"func TestValuesSeq() iter.Seq[Test] {"},
+	} {
+		r, err := Generate(
+			Paths("../../testdata/structvalidate.go"),
+			TypeNames("Test"),
+			StructValidation(true),
+			GoVersion(version),
+		)
+		if err != nil {
+			t.Fatalf("could not generate for %s: %s", version, err)
+		}
+		code, _ := ioutil.ReadAll(r)
+		if _, err := parser.ParseFile(token.NewFileSet(), "", code, 0); err != nil {
+			t.Errorf("the code for %s is not valid Go: %s\n%s", version, err, code)
+		}
+		for _, e := range expected {
+			if !strings.Contains(string(code), e) {
+				t.Errorf("expected the code for %s to contain %q:\n%s", version, e, code)
+			}
+		}
+		if version == "1.18" && (strings.Contains(string(code), "slices") || strings.Contains(string(code), "errors.Join")) {
+			t.Errorf("the code for 1.18 uses newer features:\n%s", code)
+		}
+		if strings.Contains(string(code), "slog") {
+			t.Errorf("LogValue methods should only be generated if set, for %s:\n%s", version, code)
+		}
+	}
+
+	r, err := Generate(
+		Paths("../../testdata/structvalidate.go"),
+		TypeNames("Test"),
+		GoVersion("1.21"),
+		LogValue(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, _ := ioutil.ReadAll(r)
+	if !strings.Contains(string(code), "func (v Test) LogValue() slog.Value {") {
+		t.Errorf("expected a LogValue method:\n%s", code)
+	}
+
+	_, err = Generate(
+		Paths("../../testdata/structvalidate.go"),
+		TypeNames("Test"),
+		GoVersion("2.0"),
+	)
+	if err == nil {
+		t.Fatal("expected an invalid Go version to result in an error")
+	}
+
+	for _, options := range [][]Option{
+		{GoVersion("1.20"), LogValue(true)},
+		{GoVersion("1.21"), LogRedaction(true)},
+	} {
+		_, err = Generate(append(options,
+			Paths("../../testdata/structvalidate.go"),
+			TypeNames("Test"),
+		)...)
+		if err == nil {
+			t.Error("expected LogValue methods without log/slog, or redaction without LogValue methods, to result in an error")
+		}
+	}
+}
+
+func TestListOptions(t *testing.T) {
+	for _, options := range [][]Option{
+		{ListSeparator("")},
+		{ListOrder("sorted")},
+	} {
+		_, err := Generate(append(options,
+			Paths("../../testdata/list.go"),
+			TypeNames("Country"),
+			List(true),
+		)...)
+		if err == nil {
+			t.Error("expected invalid list options to result in an error")
+		}
+	}
+
+	_, err := Generate(
+		Paths("../../testdata/list.go"),
+		TypeNames("Country"),
+		List(true),
+		ListSeparator("A"),
+	)
+	if expected := `CountryCanada contains the list separator "A" in the value "CA"`; err == nil || err.Error() != expected {
+		t.Errorf("expected the error %q, got: %v", expected, err)
+	}
+}
+
+func TestMapParamNames(t *testing.T) {
+	for expected, values := range map[string][]value{
+		"[canada unitedStates]": {{name: "CountryCanada"}, {name: "CountryUnitedStates"}},
+		"[v0 v1]":               {{name: "CountryCanada"}, {name: "CountryFn"}},
+		"[v0 v1 v2]":            {{name: "CountryCanada"}, {name: "Other"}, {name: "CountryOther"}},
+	} {
+		if names := mapParamNames("Country", values); fmt.Sprint(names) != expected {
+			t.Errorf("expected the parameter names %s, got %v", expected, names)
+		}
+	}
+}
+
+func TestHierarchyValidation(t *testing.T) {
+	for typeName, expected := range map[string]string{
+		"Orphan": `the parent "a.b" of OrphanAB is not a value of Orphan`,
+		"Empty":  `EmptyAB has an empty segment in the hierarchical value "a..b"`,
+	} {
+		_, err := Generate(
+			Paths("testdata/hierarchy.go"),
+			TypeNames(typeName),
+			Hierarchy("."),
+		)
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected the error %q for %s, got: %v", expected, typeName, err)
+		}
+	}
+}
+
+func TestAPIVersionValidation(t *testing.T) {
+	for typeName, expected := range map[string]string{
+		"Malformed": `MalformedA: malformed API version "3", expected e.g. v3 or v3.1`,
+		"Inverted":  "InvertedA: the API version range is empty, since v5 is not before until v3",
+		"Empty":     "EmptyA: the API version range is empty, since v3 is not before until v3.0",
+	} {
+		_, err := Generate(
+			Paths("testdata/apiversions.go"),
+			TypeNames(typeName),
+		)
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected the error %q for %s, got: %v", expected, typeName, err)
+		}
+	}
+}
+
+func TestSuggestionThreshold(t *testing.T) {
+	for threshold, expected := range map[int]bool{
+		0:                          false,
+		541:                        false,
+		542:                        true,
+		DefaultSuggestionThreshold: true,
+	} {
+		r, err := Generate(
+			Paths("../../testdata/many.go"),
+			TypeNames("Test"),
+			TextUnmarshaling(true),
+			SuggestionThreshold(threshold),
+		)
+		if err != nil {
+			t.Fatalf("could not generate with the threshold %d: %s", threshold, err)
+		}
+		code, _ := ioutil.ReadAll(r)
+		if suggests := strings.Contains(string(code), "func suggestTestValues(text string) []string {"); suggests != expected {
+			t.Errorf("expected suggestions to be generated with the threshold %d: %t", threshold, expected)
+		}
+	}
+}
+
+func TestOpenLogValue(t *testing.T) {
+	r, err := Generate(
+		Paths("../../testdata/open.go"),
+		TypeNames("Test"),
+		Open(true),
+		GoVersion("1.21"),
+		LogValue(true),
+		LogRedaction(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, _ := ioutil.ReadAll(r)
+	// Registered values are not declared constants, so validity must be checked with Valid
+	expected := "\tif v.Valid() {\n\t\treturn slog.GroupValue(slog.String(\"value\", string(v)), slog.Bool(\"valid\", true))\n\t}\n"
+	if !strings.Contains(string(code), expected) {
+		t.Errorf("expected LogValue to check registered values:\n%s", code)
+	}
+}
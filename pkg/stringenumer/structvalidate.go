@@ -0,0 +1,243 @@
+package stringenumer
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// generatedHeader is the start of the header of files generated by string-enumer
+const generatedHeader = "// Code generated by \"string-enumer"
+
+// validator is the interface of types with a Validate method
+var validator = types.NewInterfaceType([]*types.Func{
+	types.NewFunc(token.NoPos, nil, "Validate", types.NewSignature(nil, nil,
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Universe.Lookup("error").Type())), false)),
+}, nil).Complete()
+
+// enumStructs returns all named struct types in the package that contains fields of the types, directly or nested
+func (g *generator) enumStructs() []*types.Named {
+	scope := g.pkg.types.Scope()
+	var structs []*types.Named
+	for _, name := range scope.Names() { // Names are sorted
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || typeName.IsAlias() {
+			continue
+		}
+		named, ok := typeName.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 {
+			continue
+		}
+		if _, ok := named.Underlying().(*types.Struct); !ok {
+			continue
+		}
+		if g.containsEnum(named, map[types.Type]struct{}{}) && !g.hasOwnValidation(named) {
+			structs = append(structs, named)
+		}
+	}
+	return structs
+}
+
+// hasOwnValidation returns true if the struct has a Validate or invalidEnumFields method, or field, that is not
+// generated by string-enumer, e.g. a Validate method written by hand. No methods are generated for such structs
+func (g *generator) hasOwnValidation(named *types.Named) bool {
+	for _, name := range []string{"Validate", "invalidEnumFields"} {
+		obj, _, _ := types.LookupFieldOrMethod(named, true, g.pkg.types, name)
+		if obj != nil && !g.isGenerated(obj.Pos()) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGenerated returns true if the position is in a file generated by string-enumer, e.g. the output of an earlier run
+func (g *generator) isGenerated(pos token.Pos) bool {
+	for _, f := range g.pkg.files {
+		if pos < f.file.Pos() || pos > f.file.End() {
+			continue
+		}
+		for _, c := range f.file.Comments {
+			if c.Pos() > f.file.Package {
+				break
+			}
+			if strings.HasPrefix(c.Text(), strings.TrimPrefix(generatedHeader, "// ")) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// enumType returns the name of the type if it is one of the types code is generated for
+func (g *generator) enumType(typ types.Type) (string, bool) {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() != g.pkg.types {
+		return "", false
+	}
+	name := named.Obj().Name()
+	_, ok = g.values[name]
+	return name, ok
+}
+
+// localStruct returns true if the type is a named struct type declared in the package, that validation methods are generated for
+func (g *generator) localStruct(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() != g.pkg.types || named.TypeParams().Len() > 0 {
+		return false
+	}
+	_, ok = named.Underlying().(*types.Struct)
+	return ok && !g.hasOwnValidation(named)
+}
+
+// containsEnum returns true if a value of the type can contain any of the types code is generated for
+func (g *generator) containsEnum(typ types.Type, seen map[types.Type]struct{}) bool {
+	if _, ok := g.enumType(typ); ok {
+		return true
+	}
+	if _, ok := seen[typ]; ok {
+		return false
+	}
+	seen[typ] = struct{}{}
+
+	switch t := typ.(type) {
+	case *types.Named:
+		if t.Obj().Pkg() != g.pkg.types {
+			return false
+		}
+		return g.containsEnum(t.Underlying(), seen)
+	case *types.Pointer:
+		return g.containsEnum(t.Elem(), seen)
+	case *types.Slice:
+		return g.containsEnum(t.Elem(), seen)
+	case *types.Array:
+		return g.containsEnum(t.Elem(), seen)
+	case *types.Map:
+		return g.containsEnum(t.Key(), seen) || g.containsEnum(t.Elem(), seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if t.Field(i).Name() != "_" && g.containsEnum(t.Field(i).Type(), seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (g *generator) buildStructValidation(named *types.Named) {
+	g.addImport(`"errors"`)
+	g.addImport(`"fmt"`)
+
+	name := named.Obj().Name()
+	g.Printf("\n// Validate validates all enum fields of %s, and returns an error describing every invalid field\n", name)
+	g.Printf("func (s %s) Validate() error {\n", name)
+	if g.goAtLeast(20) {
+		g.Printf("	var errs []error\n")
+		g.Printf("	for _, invalid := range s.invalidEnumFields(\"\") {\n")
+		g.Printf("		errs = append(errs, errors.New(invalid))\n")
+		g.Printf("	}\n")
+		g.Printf("	return errors.Join(errs...)\n")
+	} else {
+		g.addImport(`"strings"`)
+		g.Printf("	if invalid := s.invalidEnumFields(\"\"); len(invalid) > 0 {\n")
+		g.Printf("		return errors.New(strings.Join(invalid, \"; \"))\n")
+		g.Printf("	}\n")
+		g.Printf("	return nil\n")
+	}
+	g.Printf("}\n\n")
+	g.Printf("// invalidEnumFields returns a description of every invalid enum field of %s, with the field paths prefixed by prefix\n", name)
+	g.Printf("func (s %s) invalidEnumFields(prefix string) []string {\n", name)
+	g.Printf("	var invalid []string\n")
+	g.printFieldsValidation(named.Underlying().(*types.Struct), "s", "prefix", "	", 0)
+	g.Printf("	return invalid\n")
+	g.Printf("}\n")
+}
+
+// printFieldsValidation prints the validation of all fields of a struct.
+// pathPrefix is a Go expression that the name of a field should be appended to
+func (g *generator) printFieldsValidation(s *types.Struct, expr, pathPrefix, indent string, depth int) {
+	for i := 0; i < s.NumFields(); i++ {
+		field := s.Field(i)
+		if field.Name() == "_" {
+			continue
+		}
+		path := appendPath(pathPrefix, field.Name())
+		g.printValidation(field.Type(), expr+"."+field.Name(), path, indent, depth)
+	}
+}
+
+// printValidation prints the validation of a Go expression of a type.
+// path is a Go expression with the field path of the validated value
+func (g *generator) printValidation(typ types.Type, expr, path, indent string, depth int) {
+	if !g.containsEnum(typ, map[types.Type]struct{}{}) {
+		return
+	}
+
+	if name, ok := g.enumType(typ); ok {
+		g.Printf("%sif !%s.Valid() {\n", indent, expr)
+		g.Printf("%s	invalid = append(invalid, fmt.Sprintf(\"%%s: not valid value for %s: %%s\", %s, %s))\n", indent, name, path, expr)
+		g.Printf("%s}\n", indent)
+		return
+	}
+	if g.localStruct(typ) {
+		g.Printf("%sinvalid = append(invalid, %s.invalidEnumFields(%s)...)\n", indent, expr, appendPath(path, "."))
+		return
+	}
+	if _, ok := typ.(*types.Named); ok && (types.Implements(typ, validator) || types.Implements(types.NewPointer(typ), validator)) {
+		// The type has its own Validate method, e.g. a struct with one written by hand. The expression is always
+		// addressable, a field of the receiver, a range variable or a dereferenced pointer, so pointer receivers work too
+		g.Printf("%sif err := %s.Validate(); err != nil {\n", indent, expr)
+		g.Printf("%s	invalid = append(invalid, fmt.Sprintf(\"%%s: %%s\", %s, err))\n", indent, path)
+		g.Printf("%s}\n", indent)
+		return
+	}
+
+	if named, ok := typ.(*types.Named); ok {
+		// Named types that are not structs are validated inline, but a recursive type can only be expanded once.
+		// Values nested deeper in a recursive type, e.g. type Tree map[Test]Tree, are therefore not validated
+		if _, ok := g.expanding[named]; ok {
+			return
+		}
+		g.expanding[named] = struct{}{}
+		defer delete(g.expanding, named)
+	}
+
+	index := fmt.Sprintf("i%d", depth)
+	elem := fmt.Sprintf("v%d", depth)
+	switch t := typ.Underlying().(type) {
+	case *types.Pointer:
+		g.Printf("%sif %s != nil {\n", indent, expr)
+		g.printValidation(t.Elem(), "(*"+expr+")", path, indent+"	", depth+1)
+		g.Printf("%s}\n", indent)
+	case *types.Slice:
+		g.Printf("%sfor %s, %s := range %s {\n", indent, index, elem, expr)
+		g.printValidation(t.Elem(), elem, fmt.Sprintf("fmt.Sprintf(\"%%s[%%d]\", %s, %s)", path, index), indent+"	", depth+1)
+		g.Printf("%s}\n", indent)
+	case *types.Array:
+		g.Printf("%sfor %s, %s := range %s {\n", indent, index, elem, expr)
+		g.printValidation(t.Elem(), elem, fmt.Sprintf("fmt.Sprintf(\"%%s[%%d]\", %s, %s)", path, index), indent+"	", depth+1)
+		g.Printf("%s}\n", indent)
+	case *types.Map:
+		key := fmt.Sprintf("k%d", depth)
+		elemPath := fmt.Sprintf("fmt.Sprintf(\"%%s[%%v]\", %s, %s)", path, key)
+		if g.containsEnum(t.Elem(), map[types.Type]struct{}{}) {
+			g.Printf("%sfor %s, %s := range %s {\n", indent, key, elem, expr)
+		} else {
+			g.Printf("%sfor %s := range %s {\n", indent, key, expr)
+		}
+		g.printValidation(t.Key(), key, elemPath, indent+"	", depth+1)
+		g.printValidation(t.Elem(), elem, elemPath, indent+"	", depth+1)
+		g.Printf("%s}\n", indent)
+	case *types.Struct:
+		g.printFieldsValidation(t, expr, appendPath(path, "."), indent, depth)
+	}
+}
+
+// appendPath returns a Go expression with the text appended to the path expression
+func appendPath(path, text string) string {
+	if strings.HasSuffix(path, `"`) {
+		return fmt.Sprintf("%s%s\"", path[:len(path)-1], text)
+	}
+	return fmt.Sprintf("%s+%q", path, text)
+}
@@ -0,0 +1,82 @@
+package stringenumer
+
+import "unicode/utf8"
+
+// DefaultSuggestionThreshold is the default maximum number of values of a type for which suggestions are generated
+const DefaultSuggestionThreshold = 1000
+
+const (
+	maxSuggestionDistance = 2 // Values more edits away from an invalid value are never suggested
+	maxSuggestions        = 3
+)
+
+// invalidError returns an expression that creates the invalid error of a type, for the value expression
+func (g *generator) invalidError(name, value string) string {
+	if g.generates("suggest", name) {
+		return g.ident("invalid-error", name) + "{Value: " + value + ", Suggestions: " + g.ident("suggest", name) + "(" + value + ")}"
+	}
+	return g.ident("invalid-error", name) + "{Value: " + value + "}"
+}
+
+func (g *generator) buildSuggestions(name string) {
+	maxLength := 0
+	for _, v := range g.values[name] {
+		if length := utf8.RuneCountInString(v.value); length > maxLength {
+			maxLength = length
+		}
+	}
+
+	g.Printf("\n// %s returns up to %d %s values that are closest to the text by edit distance, closest first.\n", g.ident("suggest", name), maxSuggestions, name)
+	g.Printf("// Values more than %d edits away, or that would be entirely replaced, are not suggested\n", maxSuggestionDistance)
+	g.Printf("func %s(text string) []string {\n", g.ident("suggest", name))
+	g.Printf("	const maxDistance = %d\n", maxSuggestionDistance)
+	g.Printf("	var byDistance [maxDistance + 1][]string\n")
+	g.Printf("	a := []rune(text)\n")
+	g.Printf("	prev, curr := make([]int, %d), make([]int, %d)\n", maxLength+1, maxLength+1)
+	g.Printf("next:\n")
+	g.Printf("	for _, v := range %s {\n", g.ident("all-values", name))
+	g.Printf("		b := []rune(string(v))\n")
+	g.Printf("		if len(a)-len(b) > maxDistance || len(b)-len(a) > maxDistance {\n")
+	g.Printf("			continue\n")
+	g.Printf("		}\n")
+	g.Printf("		for j := 0; j <= len(b); j++ {\n")
+	g.Printf("			prev[j] = j\n")
+	g.Printf("		}\n")
+	g.Printf("		for i := 1; i <= len(a); i++ {\n")
+	g.Printf("			curr[0] = i\n")
+	g.Printf("			rowMin := i\n")
+	g.Printf("			for j := 1; j <= len(b); j++ {\n")
+	g.Printf("				d := prev[j-1]\n")
+	g.Printf("				if a[i-1] != b[j-1] {\n")
+	g.Printf("					d++\n")
+	g.Printf("				}\n")
+	g.Printf("				if prev[j]+1 < d {\n")
+	g.Printf("					d = prev[j] + 1\n")
+	g.Printf("				}\n")
+	g.Printf("				if curr[j-1]+1 < d {\n")
+	g.Printf("					d = curr[j-1] + 1\n")
+	g.Printf("				}\n")
+	g.Printf("				curr[j] = d\n")
+	g.Printf("				if d < rowMin {\n")
+	g.Printf("					rowMin = d\n")
+	g.Printf("				}\n")
+	g.Printf("			}\n")
+	g.Printf("			if rowMin > maxDistance {\n")
+	g.Printf("				continue next\n")
+	g.Printf("			}\n")
+	g.Printf("			prev, curr = curr, prev\n")
+	g.Printf("		}\n")
+	g.Printf("		if d := prev[len(b)]; d <= maxDistance && d < len(b) {\n")
+	g.Printf("			byDistance[d] = append(byDistance[d], string(v))\n")
+	g.Printf("		}\n")
+	g.Printf("	}\n")
+	g.Printf("	var suggestions []string\n")
+	g.Printf("	for _, closest := range byDistance {\n")
+	g.Printf("		suggestions = append(suggestions, closest...)\n")
+	g.Printf("	}\n")
+	g.Printf("	if len(suggestions) > %d {\n", maxSuggestions)
+	g.Printf("		suggestions = suggestions[:%d]\n", maxSuggestions)
+	g.Printf("	}\n")
+	g.Printf("	return suggestions\n")
+	g.Printf("}\n")
+}
@@ -0,0 +1,42 @@
+package main
+
+// Test is a test type
+// +kubebuilder:validation:Enum=test
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test"
+	TestTest2 Test = "hello"
+)
+
+type (
+	// Other is not annotated
+	Other string
+
+	Test2 string
+)
+
+// Some Test2s
+const (
+	Test2Test Test2 = "test"
+)
+
+// Test3 is annotated without a space after the comment prefix
+//+kubebuilder:validation:Enum=old
+type Test3 string
+
+// Some Test3s
+const (
+	Test3Test Test3 = "test"
+)
+
+// Test4 has values that must be quoted in the marker
+type Test4 string
+
+// Some Test4s
+const (
+	Test4Space     Test4 = "a b"
+	Test4Semicolon Test4 = "c;d"
+	Test4Plain     Test4 = "e"
+)
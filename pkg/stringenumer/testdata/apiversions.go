@@ -0,0 +1,25 @@
+package main
+
+// Malformed is a test type with a malformed API version
+type Malformed string
+
+// Some Malformeds
+const (
+	MalformedA Malformed = "a" // enum:since=3
+)
+
+// Inverted is a test type with an inverted API version range
+type Inverted string
+
+// Some Inverteds
+const (
+	InvertedA Inverted = "a" // enum:since=v5 until=v3
+)
+
+// Empty is a test type with an empty API version range
+type Empty string
+
+// Some Empties
+const (
+	EmptyA Empty = "a" // enum:since=v3 until=v3.0
+)
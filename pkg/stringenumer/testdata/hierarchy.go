@@ -0,0 +1,19 @@
+package main
+
+// Orphan is a test type where a parent is missing
+type Orphan string
+
+// Some Orphans
+const (
+	OrphanA  Orphan = "a"
+	OrphanAB Orphan = "a.b.c"
+)
+
+// Empty is a test type with an empty segment
+type Empty string
+
+// Some Empties
+const (
+	EmptyA  Empty = "a"
+	EmptyAB Empty = "a..b"
+)
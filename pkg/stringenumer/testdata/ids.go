@@ -0,0 +1,35 @@
+package main
+
+// Incomplete is a test type where not all values have an id
+type Incomplete string
+
+// Some Incompletes
+const (
+	IncompleteA Incomplete = "a" // enum:id=1
+	IncompleteB Incomplete = "b"
+)
+
+// Duplicate is a test type where two values have the same id
+type Duplicate string
+
+// Some Duplicates
+const (
+	DuplicateA Duplicate = "a" // enum:id=1
+	DuplicateB Duplicate = "b" // enum:id=1
+)
+
+// Malformed is a test type with a malformed id
+type Malformed string
+
+// Some Malformeds
+const (
+	MalformedA Malformed = "a" // enum:id=one
+)
+
+// Unknown is a test type with an unknown directive
+type Unknown string
+
+// Some Unknowns
+const (
+	UnknownA Unknown = "a" // enum:id=1 identifier=1
+)
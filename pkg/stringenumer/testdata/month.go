@@ -0,0 +1,33 @@
+package main
+
+import "time"
+
+var _ time.Month
+
+// Month is a test type matching time.Month
+type Month string
+
+// All Months
+const (
+	MonthJanuary   Month = "january"
+	MonthFebruary  Month = "february"
+	MonthMarch     Month = "march"
+	MonthApril     Month = "april"
+	MonthMay       Month = "may"
+	MonthJune      Month = "june"
+	MonthJuly      Month = "july"
+	MonthAugust    Month = "august"
+	MonthSeptember Month = "september"
+	MonthOctober   Month = "october"
+	MonthNovember  Month = "november"
+	MonthDecember  Month = "december"
+)
+
+// SomeMonth is a test type that only matches some values of time.Month
+type SomeMonth string
+
+// Some Months
+const (
+	SomeMonthJanuary SomeMonth = "january"
+	SomeMonthSmarch  SomeMonth = "smarch"
+)
@@ -0,0 +1,47 @@
+package main
+
+import "errors"
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest Test = "test"
+)
+
+// Request is a struct with a Validate method written by hand
+type Request struct {
+	Test Test
+}
+
+// Validate validates the request
+func (r Request) Validate() error {
+	if !r.Test.Valid() {
+		return errors.New("invalid test")
+	}
+	return nil
+}
+
+// Pointer is a struct with a Validate method written by hand, with a pointer receiver
+type Pointer struct {
+	Test Test
+}
+
+// Validate validates the pointer
+func (p *Pointer) Validate() error {
+	if !p.Test.Valid() {
+		return errors.New("invalid pointer test")
+	}
+	return nil
+}
+
+// Outer is a struct that validation is generated for, with fields of structs with their own Validate methods
+type Outer struct {
+	Test     Test
+	Request  Request
+	Pointer  Pointer
+	Pointers []Pointer
+}
+
+func main() {}
@@ -0,0 +1,10 @@
+package main
+
+// Test is a test type
+type Test string
+
+// Tests where the short name of TestA collides with the constant A
+const (
+	TestA Test = "a"
+	A     Test = "b"
+)
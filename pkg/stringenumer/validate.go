@@ -0,0 +1,90 @@
+package stringenumer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxAllowedValues is the maximum number of allowed values listed in the error of an invalid value
+const maxAllowedValues = 10
+
+// allowedValues returns the description of the allowed values in the error of an invalid value.
+// The values are quoted, and only the first maxAllowedValues are listed
+func allowedValues(values []string) string {
+	quoted := make([]string, 0, maxAllowedValues+1)
+	for i, v := range values {
+		if i == maxAllowedValues {
+			quoted = append(quoted, fmt.Sprintf("... and %d more", len(values)-i))
+			break
+		}
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	return fmt.Sprintf(" (allowed values: %s)", strings.Join(quoted, ", "))
+}
+
+func (g *generator) buildInvalidError(name string) {
+	if g.generates("suggest", name) {
+		g.addImport(`"strings"`)
+	}
+	values := make([]string, len(g.values[name]))
+	for i, v := range g.values[name] {
+		values[i] = v.value
+	}
+
+	g.Printf("\n// %s is the error returned when a value is not a valid %s\n", g.ident("invalid-error", name), name)
+	g.Printf("type %s struct {\n", g.ident("invalid-error", name))
+	g.Printf("	Value string\n")
+	if g.generates("suggest", name) {
+		g.Printf("	// Suggestions are the %s values closest to the invalid value, closest first\n", name)
+		g.Printf("	Suggestions []string\n")
+	}
+	g.Printf("}\n\n")
+	g.Printf("// Error returns a description of the invalid value, with the first %d allowed %s values", maxAllowedValues, name)
+	if g.generates("suggest", name) {
+		g.Printf(" and the suggested ones, if any")
+	}
+	g.Printf("\n")
+	g.Printf("func (e %s) Error() string {\n", g.ident("invalid-error", name))
+	if g.open {
+		// The registered values are only known at runtime
+		g.addImport(`"fmt"`)
+		g.addImport(`"strconv"`)
+		g.addImport(`"strings"`)
+		g.Printf("	values := %s()\n", g.ident("values", name))
+		g.Printf("	allowed := make([]string, 0, %d)\n", maxAllowedValues+1)
+		g.Printf("	for i, v := range values {\n")
+		g.Printf("		if i == %d {\n", maxAllowedValues)
+		g.Printf("			allowed = append(allowed, fmt.Sprintf(\"... and %%d more\", len(values)-i))\n")
+		g.Printf("			break\n")
+		g.Printf("		}\n")
+		g.Printf("		allowed = append(allowed, strconv.Quote(string(v)))\n")
+		g.Printf("	}\n")
+		g.Printf("	message := %q + e.Value + \" (allowed values: \" + strings.Join(allowed, \", \") + \")\"\n", fmt.Sprintf("not valid value for %s: ", name))
+	} else {
+		g.Printf("	message := %q + e.Value + %q\n", fmt.Sprintf("not valid value for %s: ", name), allowedValues(values))
+	}
+	if g.generates("suggest", name) {
+		g.addImport(`"strconv"`)
+		g.Printf("	if len(e.Suggestions) > 0 {\n")
+		g.Printf("		suggestions := make([]string, len(e.Suggestions))\n")
+		g.Printf("		for i, suggestion := range e.Suggestions {\n")
+		g.Printf("			suggestions[i] = strconv.Quote(suggestion)\n")
+		g.Printf("		}\n")
+		g.Printf("		message += \"; did you mean \" + strings.Join(suggestions, \" or \") + \"?\"\n")
+		g.Printf("	}\n")
+	}
+	g.Printf("	return message\n")
+	g.Printf("}\n")
+}
+
+func (g *generator) buildValidate(name string) {
+	g.Printf("\n// Validate returns an %s if the value is not a valid %s.\n", g.ident("invalid-error", name), name)
+	g.Printf("// It makes %s usable with validation libraries, e.g. ozzo-validation\n", name)
+	g.Printf("func (v %s) Validate() error {\n", name)
+	g.Printf("	if !v.Valid() {\n")
+	g.Printf("		return %s\n", g.invalidError(name, "string(v)"))
+	g.Printf("	}\n")
+	g.Printf("	return nil\n")
+	g.Printf("}\n")
+}
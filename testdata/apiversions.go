@@ -0,0 +1,61 @@
+// extra-parameters: --type Country
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Country is a country code
+type Country string
+
+// Some Countries
+const (
+	CountryCanada Country = "CA"
+	CountrySweden Country = "SE" // enum:since=v3
+	// Removed when the union was formed
+	//
+	// enum:until=v5
+	CountryYugoslavia Country = "YU"
+	CountryPatched    Country = "PA" // enum:since=v3.1 until=v4
+)
+
+func main() {
+	for version, expected := range map[string]string{
+		"v1":   "[CA YU]",
+		"v3":   "[CA SE YU]",
+		"v3.0": "[CA SE YU]",
+		"v3.1": "[CA SE YU PA]",
+		"v4":   "[CA SE YU]",
+		"v5":   "[CA SE]",
+		"v10":  "[CA SE]",
+		"3":    "[]",
+		"v":    "[]",
+		"v-1":  "[]",
+	} {
+		if values := CountryValuesFor(version); fmt.Sprint(values) != expected {
+			panic(fmt.Sprintf("wrong values for %s: %v", version, values))
+		}
+	}
+
+	if Country("XX").ValidFor("v3") {
+		panic("an invalid value should not be valid for any version")
+	}
+
+	var c Country
+	if err := c.UnmarshalTextFor("v5", []byte("SE")); err != nil || c != CountrySweden {
+		panic(fmt.Sprintf("could not unmarshal: %v", err))
+	}
+	err := c.UnmarshalTextFor("v2", []byte("SE"))
+	if err == nil || err.Error() != `not valid in API version v2: not valid value for Country: SE (allowed values: "CA", "SE", "YU", "PA")` {
+		panic(fmt.Sprintf("expected a value that is not introduced to be rejected: %v", err))
+	}
+	var invalid InvalidCountryError
+	if !errors.As(err, &invalid) || invalid.Value != "SE" {
+		panic(fmt.Sprintf("expected a typed error: %v", err))
+	}
+	err = c.UnmarshalTextFor("v2", []byte("SW"))
+	if !errors.As(err, &invalid) || len(invalid.Suggestions) != 1 || invalid.Suggestions[0] != "SE" {
+		panic(fmt.Sprintf("expected an invalid value to have suggestions: %v", err))
+	}
+}
@@ -0,0 +1,36 @@
+// extra-parameters: --completion --type Test
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test" // A test
+	TestTest2 Test = "testing"
+	TestTest3 Test = "hello" // Greeting
+)
+
+func main() {
+	tests := []struct {
+		toComplete string
+		expected   []string
+	}{
+		{"", []string{"test\tA test", "testing", "hello\tGreeting"}},
+		{"te", []string{"test\tA test", "testing"}},
+		{"testi", []string{"testing"}},
+		{"x", nil},
+	}
+
+	for _, test := range tests {
+		got := TestCompletions(test.toComplete)
+		if strings.Join(got, "|") != strings.Join(test.expected, "|") {
+			panic(fmt.Sprintf("wrong completions of %q: %q", test.toComplete, got))
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// extra-parameters: --type Test
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test"
+	TestTest2 Test = "hello"
+	TestTest3 Test = "world"
+)
+
+func main() {
+	if TestCount != 3 {
+		panic(fmt.Sprintf("wrong count of Test values: %d", TestCount))
+	}
+
+	values := TestValuesArray()
+	for i := 0; i < TestCount; i++ {
+		if TestValueAt(i) != values[i] || values[i] != TestValues()[i] {
+			panic(fmt.Sprintf("wrong value at %d: %s", i, TestValueAt(i)))
+		}
+	}
+
+	// The returned array should be a copy
+	values[0] = "changed"
+	if TestValueAt(0) != TestTest {
+		panic("the values array could be changed")
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		values := TestValuesArray()
+		for i := range values {
+			_ = values[i].Valid()
+		}
+		_ = TestValueAt(1)
+	})
+	if allocs != 0 {
+		panic(fmt.Sprintf("accessing the values array allocated %f times", allocs))
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				panic("TestValueAt with an out of range index should panic")
+			}
+		}()
+		TestValueAt(TestCount)
+	}()
+}
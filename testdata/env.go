@@ -0,0 +1,56 @@
+// extra-parameters: --env --type Test
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test"
+	TestTest2 Test = "hello"
+)
+
+// decoder is the interface used by envconfig
+type decoder interface {
+	Decode(value string) error
+}
+
+// setter is the interface used by cleanenv
+type setter interface {
+	SetValue(string) error
+}
+
+func main() {
+	os.Setenv("TEST_VALUE", "hello")
+
+	var decoded Test
+	var d decoder = &decoded
+	if err := d.Decode(os.Getenv("TEST_VALUE")); err != nil || decoded != TestTest2 {
+		panic(fmt.Sprintf("could not decode: %v", err))
+	}
+	if err := d.Decode("test2"); err == nil {
+		panic("could decode invalid input")
+	}
+
+	var set Test
+	var s setter = &set
+	if err := s.SetValue("test"); err != nil || set != TestTest {
+		panic(fmt.Sprintf("could not set value: %v", err))
+	}
+	if err := s.SetValue("test2"); err == nil {
+		panic("could set invalid input")
+	}
+
+	if parsed, err := ParseTest("hello"); err != nil || parsed != TestTest2 {
+		panic(fmt.Sprintf("could not parse: %v", err))
+	}
+
+	if help := TestEnvHelp(); help != `allowed values: "test", "hello"` {
+		panic(fmt.Sprintf("wrong env help: %s", help))
+	}
+}
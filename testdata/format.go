@@ -0,0 +1,46 @@
+// extra-parameters: --format --type Test
+package main
+
+import (
+	"fmt"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test"
+	TestTest2 Test = "hello"
+)
+
+func main() {
+	tests := []struct {
+		format   string
+		value    interface{}
+		expected string
+	}{
+		{"%s", TestTest, "test"},
+		{"%v", TestTest2, "hello"},
+		{"%q", TestTest, `"test"`},
+		{"%+v", TestTest2, "TestTest2"},
+		{"%#v", TestTest2, "main.TestTest2"},
+		{"%6s|", TestTest, "  test|"},
+		{"%-6v|", TestTest, "test  |"},
+		{"%x", TestTest, "74657374"},
+		{"%.2s", TestTest2, "he"},
+		{"%#q", TestTest, "`test`"},
+		{"%6.2v|", TestTest2, "    he|"},
+		{"%+q", Test("héllo"), `"h\u00e9llo"`},
+		{"%s", Test("test2"), "test2"},
+		{"%+v", Test("test2"), `Test("test2")`},
+		{"%#v", Test("test2"), `main.Test("test2")`},
+		{"%#v", struct{ T Test }{TestTest}, "struct { T main.Test }{T:main.TestTest}"},
+	}
+
+	for _, test := range tests {
+		if got := fmt.Sprintf(test.format, test.value); got != test.expected {
+			panic(fmt.Sprintf("formatting with %s resulted in %s, expected %s", test.format, got, test.expected))
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// extra-parameters: --text --fuzz --type Test
+package main
+
+import (
+	"fmt"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test"
+	TestTest2 Test = "Hello"
+	TestÅ     Test = "ö"
+)
+
+func main() {
+	if ok := TestTest2.Valid(); !ok {
+		panic(fmt.Sprintf("should be valid Test"))
+	}
+
+	var test Test
+	if err := test.UnmarshalText([]byte("HELLO")); err == nil {
+		panic("could unmarshal with invalid input")
+	}
+}
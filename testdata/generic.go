@@ -0,0 +1,34 @@
+// extra-parameters: --generic --type Test
+package main
+
+import (
+	"fmt"
+
+	"github.com/lindell/string-enumer/pkg/enum"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test"
+	TestTest2 Test = "hello"
+)
+
+func main() {
+	if values := enum.Values[Test](); len(values) != 2 || values[0] != TestTest || values[1] != TestTest2 {
+		panic(fmt.Sprintf("wrong values: %v", values))
+	}
+
+	if v, err := enum.Parse[Test]("hello"); err != nil || v != TestTest2 {
+		panic(fmt.Sprintf("could not parse: %v", err))
+	}
+	if _, err := enum.Parse[Test]("test2"); err == nil {
+		panic("could parse invalid input")
+	}
+
+	if description := enum.Describe[Test](); fmt.Sprint(description.Enum) != "[test hello]" {
+		panic(fmt.Sprintf("wrong description: %+v", description))
+	}
+}
@@ -0,0 +1,37 @@
+// extra-parameters: --type Test --struct-validate
+package main
+
+import (
+	"fmt"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test"
+	TestTest2 Test = "hello"
+)
+
+// Config has enum fields
+type Config struct {
+	First  Test
+	Second Test
+}
+
+func main() {
+	err := Config{First: "a", Second: "b"}.Validate()
+	if err == nil {
+		panic("expected invalid fields to result in an error")
+	}
+
+	// The errors of all invalid fields are joined
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 2 {
+		panic(fmt.Sprintf("expected two joined errors: %v", err))
+	}
+	if err := (Config{First: TestTest, Second: TestTest2}).Validate(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,49 @@
+// extra-parameters: --type Test --slog
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test"
+	TestTest2 Test = "hello"
+)
+
+func main() {
+	values := TestValues()
+	if len(values) != 2 || values[0] != TestTest || values[1] != TestTest2 {
+		panic(fmt.Sprintf("wrong values: %v", values))
+	}
+
+	// The returned values should be a copy
+	values[0] = "changed"
+	if TestValues()[0] != TestTest {
+		panic("the values could be changed")
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: removeTime}))
+	logger.Info("valid", "test", TestTest2)
+	logger.Info("invalid", "test", Test("world"))
+	expected := `level=INFO msg=valid test.value=hello test.name=TestTest2 test.valid=true
+level=INFO msg=invalid test.value=world test.valid=false
+`
+	if buf.String() != expected {
+		panic(fmt.Sprintf("wrong log output:\n%s", buf.String()))
+	}
+}
+
+// removeTime removes the time from log records, to make the output deterministic
+func removeTime(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.TimeKey && len(groups) == 0 {
+		return slog.Attr{}
+	}
+	return a
+}
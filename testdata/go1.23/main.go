@@ -0,0 +1,36 @@
+// extra-parameters: --type Test
+package main
+
+import (
+	"fmt"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test"
+	TestTest2 Test = "hello"
+	TestTest3 Test = "world"
+)
+
+func main() {
+	var values []Test
+	for v := range TestValuesSeq() {
+		values = append(values, v)
+	}
+	if fmt.Sprint(values) != "[test hello world]" {
+		panic(fmt.Sprintf("wrong values: %v", values))
+	}
+
+	// Breaking the loop should stop the iteration
+	count := 0
+	for range TestValuesSeq() {
+		count++
+		break
+	}
+	if count != 1 {
+		panic(fmt.Sprintf("wrong number of iterations: %d", count))
+	}
+}
@@ -0,0 +1,57 @@
+// extra-parameters: --type Topic --hierarchy .
+package main
+
+import (
+	"fmt"
+)
+
+// Topic is a hierarchical event topic
+type Topic string
+
+// Some Topics
+const (
+	TopicBilling        Topic = "billing"
+	TopicBillingInvoice Topic = "billing.invoice"
+	TopicInvoicePaid    Topic = "billing.invoice.paid"
+	TopicInvoiceVoided  Topic = "billing.invoice.voided"
+	TopicBillingRefund  Topic = "billing.refund"
+	TopicUsers          Topic = "users"
+)
+
+func main() {
+	if p, ok := TopicInvoicePaid.Parent(); !ok || p != TopicBillingInvoice {
+		panic(fmt.Sprintf("wrong parent: %s", p))
+	}
+	if _, ok := TopicBilling.Parent(); ok {
+		panic("a root value should not have a parent")
+	}
+	if _, ok := Topic("billing.other").Parent(); ok {
+		panic("an invalid value should not have a parent")
+	}
+
+	if children := TopicBilling.Children(); fmt.Sprint(children) != "[billing.invoice billing.refund]" {
+		panic(fmt.Sprintf("wrong children: %v", children))
+	}
+	if children := TopicUsers.Children(); children != nil {
+		panic(fmt.Sprintf("a leaf should not have children: %v", children))
+	}
+
+	if !TopicInvoiceVoided.IsDescendantOf(TopicBilling) || !TopicInvoiceVoided.IsDescendantOf(TopicBillingInvoice) {
+		panic("billing.invoice.voided should be a descendant of billing and billing.invoice")
+	}
+	if TopicBilling.IsDescendantOf(TopicBilling) || TopicBillingRefund.IsDescendantOf(TopicBillingInvoice) || TopicUsers.IsDescendantOf(TopicBilling) {
+		panic("wrong descendant")
+	}
+
+	for topic, depth := range map[Topic]int{
+		TopicBilling:        0,
+		TopicBillingInvoice: 1,
+		TopicInvoicePaid:    2,
+		TopicUsers:          0,
+		"invalid":           -1,
+	} {
+		if d := topic.Depth(); d != depth {
+			panic(fmt.Sprintf("wrong depth of %s: %d", topic, d))
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// extra-parameters: --html --type Test
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test" // A test
+	TestTest2 Test = "hello"
+	// Greeting to everyone
+	TestTest3 Test = "world"
+)
+
+var tmpl = template.Must(template.New("select").Parse(
+	`<select name="test">{{range .}}<option value="{{.Value}}"{{if .Selected}} selected{{end}}>{{.Label}}</option>{{end}}</select>`,
+))
+
+func main() {
+	options := TestOptions(TestTest2)
+	expected := []TestOption{
+		{Value: "test", Label: "A test"},
+		{Value: "hello", Label: "TestTest2", Selected: true},
+		{Value: "world", Label: "Greeting to everyone"},
+		{Value: "later", Label: "Later is declared separately"},
+	}
+	if len(options) != len(expected) {
+		panic(fmt.Sprintf("wrong number of options: %d", len(options)))
+	}
+	for i := range expected {
+		if options[i] != expected[i] {
+			panic(fmt.Sprintf("wrong option at %d: %+v", i, options[i]))
+		}
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, options); err != nil {
+		panic(err)
+	}
+	if !strings.Contains(b.String(), `<option value="hello" selected>TestTest2</option>`) {
+		panic(fmt.Sprintf("wrong template output: %s", b.String()))
+	}
+
+	if v, err := TestFromFormValue("world"); err != nil || v != TestTest3 {
+		panic(fmt.Sprintf("could not decode form value: %v", err))
+	}
+	if _, err := TestFromFormValue("test2"); err == nil {
+		panic("could decode invalid form value")
+	}
+}
+
+// Later is declared separately
+const TestLater Test = "later"
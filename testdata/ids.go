@@ -0,0 +1,57 @@
+// extra-parameters: --type Test
+package main
+
+import (
+	"encoding"
+	"fmt"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest Test = "test" // enum:id=7
+	// enum:id=2
+	TestTest2 Test = "hello" // A greeting
+	TestTest3 Test = "world" // enum:id=12
+)
+
+func main() {
+	var _ encoding.BinaryMarshaler = TestTest
+	var _ encoding.BinaryUnmarshaler = new(Test)
+
+	for v, id := range map[Test]int{TestTest: 7, TestTest2: 2, TestTest3: 12} {
+		if v.ID() != id {
+			panic(fmt.Sprintf("wrong id of %s: %d", v, v.ID()))
+		}
+		if got, ok := TestFromID(id); !ok || got != v {
+			panic(fmt.Sprintf("wrong value of id %d: %s", id, got))
+		}
+
+		data, err := v.MarshalBinary()
+		if err != nil {
+			panic(err)
+		}
+		var unmarshaled Test
+		if err := unmarshaled.UnmarshalBinary(data); err != nil || unmarshaled != v {
+			panic(fmt.Sprintf("%s does not round-trip: %v", v, err))
+		}
+	}
+
+	if id := Test("test2").ID(); id != -1 {
+		panic(fmt.Sprintf("invalid value should not have an id: %d", id))
+	}
+	if _, ok := TestFromID(3); ok {
+		panic("should not get value from an unused id")
+	}
+	if _, err := Test("test2").MarshalBinary(); err == nil {
+		panic("could marshal invalid value")
+	}
+	var test Test
+	for _, data := range [][]byte{{3}, {}, {7, 7}, {0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}} {
+		if err := test.UnmarshalBinary(data); err == nil {
+			panic(fmt.Sprintf("could unmarshal invalid data %x", data))
+		}
+	}
+}
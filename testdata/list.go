@@ -0,0 +1,88 @@
+// extra-parameters: --type Country --list
+package main
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Country is a country code
+type Country string
+
+// Some Countries
+const (
+	CountryCanada Country = "CA"
+	CountrySweden Country = "SE"
+	CountryWeird  Country = `we"ird }\`
+)
+
+var (
+	_ sql.Scanner   = &CountryList{}
+	_ driver.Valuer = CountryList{}
+)
+
+func main() {
+	query, _ := url.ParseQuery("countries=SE,CA,SE")
+	var list CountryList
+	if err := list.UnmarshalText([]byte(query.Get("countries"))); err != nil {
+		panic(err)
+	}
+	if fmt.Sprint(list) != "[SE CA SE]" {
+		panic(fmt.Sprintf("wrong list: %v", list))
+	}
+	if text, err := list.MarshalText(); err != nil || string(text) != "SE,CA,SE" {
+		panic(fmt.Sprintf("wrong text: %s, %v", text, err))
+	}
+
+	// The index of the first invalid element is reported
+	err := list.UnmarshalText([]byte("SE,US,XX"))
+	var invalid InvalidCountryError
+	if err == nil || err.Error() != `invalid element at index 1 of CountryList: not valid value for Country: US (allowed values: "CA", "SE", "we\"ird }\\")` {
+		panic(fmt.Sprintf("wrong error: %v", err))
+	}
+	if !errors.As(err, &invalid) || invalid.Value != "US" {
+		panic(fmt.Sprintf("expected a typed error: %v", err))
+	}
+	if _, err := (CountryList{CountryCanada, "US"}).MarshalText(); err == nil {
+		panic("should not marshal an invalid element")
+	}
+
+	if err := list.UnmarshalText(nil); err != nil || list == nil || len(list) != 0 {
+		panic(fmt.Sprintf("an empty text should be an empty list: %v, %v", list, err))
+	}
+
+	var s struct {
+		Countries CountryList `json:"countries"`
+	}
+	if err := json.Unmarshal([]byte(`{"countries":"CA,SE"}`), &s); err != nil || len(s.Countries) != 2 {
+		panic(fmt.Sprintf("could not unmarshal JSON: %v, %v", s, err))
+	}
+
+	// Postgres arrays
+	value, err := CountryList{CountrySweden, CountryWeird}.Value()
+	if err != nil || value != `{"SE","we\"ird }\\"}` {
+		panic(fmt.Sprintf("wrong array literal: %v, %v", value, err))
+	}
+	if err := list.Scan(value); err != nil || len(list) != 2 || list[1] != CountryWeird {
+		panic(fmt.Sprintf("could not scan the array literal: %v, %v", list, err))
+	}
+	if err := list.Scan([]byte("{CA,SE}")); err != nil || fmt.Sprint(list) != "[CA SE]" {
+		panic(fmt.Sprintf("could not scan an unquoted array literal: %v, %v", list, err))
+	}
+	if err := list.Scan("{}"); err != nil || len(list) != 0 {
+		panic(fmt.Sprintf("could not scan an empty array literal: %v, %v", list, err))
+	}
+	if err := list.Scan("{CA,US}"); !errors.As(err, &invalid) {
+		panic(fmt.Sprintf("should not scan an invalid element: %v", err))
+	}
+	if err := list.Scan("CA"); err == nil {
+		panic("should not scan a value that is not an array literal")
+	}
+	if value, err := CountryList(nil).Value(); err != nil || value != nil {
+		panic(fmt.Sprintf("a nil list should be NULL: %v, %v", value, err))
+	}
+}
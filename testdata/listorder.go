@@ -0,0 +1,32 @@
+// extra-parameters: --type Country --list --list-separator | --list-dedupe --list-order declaration
+package main
+
+import (
+	"fmt"
+)
+
+// Country is a country code
+type Country string
+
+// Some Countries
+const (
+	CountryCanada Country = "CA"
+	CountryChina  Country = "CN"
+	CountrySweden Country = "SE"
+)
+
+func main() {
+	var list CountryList
+	if err := list.UnmarshalText([]byte("SE|CA|SE|CN|CA")); err != nil {
+		panic(err)
+	}
+	if fmt.Sprint(list) != "[CA CN SE]" {
+		panic(fmt.Sprintf("wrong list: %v", list))
+	}
+	if text, _ := list.MarshalText(); string(text) != "CA|CN|SE" {
+		panic(fmt.Sprintf("wrong text: %s", text))
+	}
+	if err := list.Scan("{SE,SE,CA}"); err != nil || fmt.Sprint(list) != "[CA SE]" {
+		panic(fmt.Sprintf("wrong scanned list: %v, %v", list, err))
+	}
+}
@@ -0,0 +1,76 @@
+// extra-parameters: --type Country --map
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Country is a country code
+type Country string
+
+// Some Countries
+const (
+	CountryCanada Country = "CA"
+	CountrySweden Country = "SE"
+	CountryUSA    Country = "US"
+)
+
+// Settings are per-country settings
+type Settings struct {
+	Currency string   `json:"currency"`
+	Regions  []string `json:"regions"`
+}
+
+func main() {
+	m := NewCountryMap(
+		func() Settings { return Settings{Currency: "CAD"} },
+		func() Settings { return Settings{Currency: "SEK"} },
+		func() Settings { return Settings{Currency: "USD"} },
+	)
+
+	if s, ok := m.Get(CountrySweden); !ok || s.Currency != "SEK" {
+		panic(fmt.Sprintf("wrong value of Sweden: %v", s))
+	}
+	if _, ok := m.Get("XX"); ok {
+		panic("should not get an invalid key")
+	}
+	if !m.Set(CountryUSA, Settings{Currency: "USD", Regions: []string{"west"}}) || m.Set("XX", Settings{}) {
+		panic("wrong result of Set")
+	}
+
+	var keys []Country
+	m.Range(func(key Country, value Settings) bool {
+		keys = append(keys, key)
+		return key != CountrySweden
+	})
+	if fmt.Sprint(keys) != "[CA SE]" {
+		panic(fmt.Sprintf("wrong range: %v", keys))
+	}
+
+	data, err := json.Marshal(m)
+	expected := `{"CA":{"currency":"CAD","regions":null},"SE":{"currency":"SEK","regions":null},"US":{"currency":"USD","regions":["west"]}}`
+	if err != nil || string(data) != expected {
+		panic(fmt.Sprintf("wrong JSON: %s, %v", data, err))
+	}
+
+	var unmarshaled CountryMap[Settings]
+	if err := json.Unmarshal(data, &unmarshaled); err != nil || fmt.Sprint(unmarshaled) != fmt.Sprint(m) {
+		panic(fmt.Sprintf("could not unmarshal JSON: %v, %v", unmarshaled, err))
+	}
+
+	// Unknown keys are rejected, and all missing keys are reported
+	var invalid InvalidCountryError
+	err = json.Unmarshal([]byte(`{"CA":{},"SE":{},"US":{},"XX":{}}`), &unmarshaled)
+	if !errors.As(err, &invalid) || invalid.Value != "XX" {
+		panic(fmt.Sprintf("expected an unknown key to be rejected: %v", err))
+	}
+	err = json.Unmarshal([]byte(`{"SE":{}}`), &unmarshaled)
+	if err == nil || err.Error() != "missing keys in CountryMap: CA, US" {
+		panic(fmt.Sprintf("expected missing keys to be reported: %v", err))
+	}
+	if s, _ := unmarshaled.Get(CountryCanada); s.Currency != "CAD" {
+		panic("a failed unmarshal should not change the map")
+	}
+}
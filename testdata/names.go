@@ -0,0 +1,45 @@
+// extra-parameters: --names --type Test
+package main
+
+import (
+	"fmt"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test"
+	TestTest2 Test = "hello"
+	Other     Test = "other"
+)
+
+func main() {
+	if name := TestTest2.Name(); name != "TestTest2" {
+		panic(fmt.Sprintf("wrong name of TestTest2: %s", name))
+	}
+	if name := TestTest2.ShortName(); name != "Test2" {
+		panic(fmt.Sprintf("wrong short name of TestTest2: %s", name))
+	}
+	if name := Other.ShortName(); name != "Other" {
+		panic(fmt.Sprintf("wrong short name of Other: %s", name))
+	}
+	if name := Test("test2").Name(); name != "" {
+		panic(fmt.Sprintf("invalid value should not have a name: %s", name))
+	}
+
+	for _, name := range []string{"TestTest", "Test"} {
+		if v, ok := TestFromName(name); !ok || v != TestTest {
+			panic(fmt.Sprintf("could not get TestTest from %s", name))
+		}
+	}
+	if _, ok := TestFromName("test"); ok {
+		panic("should not get value from a value")
+	}
+
+	names := TestNames()
+	if len(names) != 3 || names[0] != "TestTest" || names[1] != "TestTest2" || names[2] != "Other" {
+		panic(fmt.Sprintf("wrong names: %v", names))
+	}
+}
@@ -0,0 +1,25 @@
+// extra-parameters: --type Test --text --name values={{.Name}}List,parse=Must{{.Name}},count=Num{{.Name}}
+package main
+
+import (
+	"fmt"
+)
+
+type Test string
+
+const (
+	TestA Test = "a"
+	TestB Test = "b"
+)
+
+func main() {
+	if values := TestList(); len(values) != NumTest {
+		panic(fmt.Sprintf("wrong values: %v", values))
+	}
+	if v, err := MustTest("b"); err != nil || v != TestB {
+		panic(fmt.Sprintf("could not parse b: %v", err))
+	}
+	if v := TestValueAt(0); v != TestA {
+		panic(fmt.Sprintf("wrong first value: %s", v))
+	}
+}
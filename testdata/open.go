@@ -0,0 +1,108 @@
+// extra-parameters: --open --text --env --html --completion --map --list --list-order declaration --type Test
+// run-parameters: -race
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test" // A test
+	TestTest2 Test = "hello"
+)
+
+func main() {
+	if err := RegisterTest(TestTest, TestMeta{}); err == nil {
+		panic("registering a declared value should fail")
+	}
+	if err := RegisterTest("", TestMeta{}); err == nil {
+		panic("registering an empty value should fail")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			if err := RegisterTest(Test(fmt.Sprintf("plugin%d", i)), TestMeta{Description: "From a plugin"}); err != nil {
+				panic(err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			_ = Test("plugin0").Valid()
+			_ = TestValues()
+		}()
+	}
+	wg.Wait()
+
+	if len(TestValues()) != TestCount+10 {
+		panic(fmt.Sprintf("wrong number of values: %v", TestValues()))
+	}
+	if values := TestValues(); values[0] != TestTest || values[1] != TestTest2 {
+		panic(fmt.Sprintf("declared values should be first: %v", values))
+	}
+
+	var t Test
+	if err := json.Unmarshal([]byte(`"plugin3"`), &t); err != nil {
+		panic(fmt.Sprintf("registered value should be valid: %s", err))
+	}
+	if meta, ok := t.Meta(); !ok || meta.Description != "From a plugin" {
+		panic(fmt.Sprintf("wrong meta of registered value: %+v", meta))
+	}
+	if meta, ok := TestTest.Meta(); !ok || meta.Description != "A test" {
+		panic(fmt.Sprintf("wrong meta of declared value: %+v", meta))
+	}
+	if _, ok := Test("world").Meta(); ok {
+		panic("invalid value should not have meta")
+	}
+	err := json.Unmarshal([]byte(`"world"`), &t)
+	if err == nil {
+		panic("unregistered value should not be valid")
+	}
+	// The declared values are listed first, and then the registered, which are registered in random order
+	if message := err.Error(); !strings.HasPrefix(message, `not valid value for Test: world (allowed values: "test", "hello", "plugin`) || !strings.HasSuffix(message, ", ... and 2 more)") {
+		panic(fmt.Sprintf("the error should list registered values: %s", message))
+	}
+
+	// Registered values are not dropped when lists are sorted in declaration order
+	var list TestList
+	if err := list.UnmarshalText([]byte("plugin3,hello,test")); err != nil {
+		panic(err)
+	}
+	if fmt.Sprint(list) != "[test hello plugin3]" {
+		panic(fmt.Sprintf("wrong list: %v", list))
+	}
+
+	options := TestOptions("plugin3")
+	if len(options) != TestCount+10 {
+		panic(fmt.Sprintf("wrong number of options: %d", len(options)))
+	}
+	for _, option := range options[TestCount:] {
+		if option.Label != "From a plugin" || option.Selected != (option.Value == "plugin3") {
+			panic(fmt.Sprintf("wrong option of a registered value: %+v", option))
+		}
+	}
+
+	if completions := TestCompletions("plugin"); len(completions) != 10 || !strings.HasSuffix(completions[0], "\tFrom a plugin") {
+		panic(fmt.Sprintf("wrong completions: %v", completions))
+	}
+
+	if help := TestEnvHelp(); !strings.HasPrefix(help, `allowed values: "test", "hello", "plugin`) || !strings.Contains(help, `"plugin3"`) {
+		panic(fmt.Sprintf("wrong env help: %s", help))
+	}
+
+	// Only declared values are keys of maps
+	var m TestMap[int]
+	err = json.Unmarshal([]byte(`{"test":1,"hello":2,"plugin3":3}`), &m)
+	if err == nil || !strings.Contains(err.Error(), "plugin3 is registered at runtime") {
+		panic(fmt.Sprintf("a registered key should be rejected: %v", err))
+	}
+}
@@ -0,0 +1,59 @@
+// extra-parameters: --orm --type Test
+package main
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test"
+	TestTest2 Test = "hello"
+)
+
+// enumValues is the interface used by ent
+type enumValues interface {
+	Values() []string
+}
+
+// gormDataType is the interface used by GORM
+type gormDataType interface {
+	GormDataType() string
+}
+
+func main() {
+	var _ enumValues = Test("")
+	var _ gormDataType = Test("")
+	var _ sql.Scanner = new(Test)
+	var _ driver.Valuer = Test("")
+
+	if values := Test("").Values(); fmt.Sprint(values) != "[test hello]" {
+		panic(fmt.Sprintf("wrong values: %v", values))
+	}
+
+	var test Test
+	if err := test.Scan("hello"); err != nil || test != TestTest2 {
+		panic(fmt.Sprintf("could not scan string: %v", err))
+	}
+	if err := test.Scan([]byte("test")); err != nil || test != TestTest {
+		panic(fmt.Sprintf("could not scan bytes: %v", err))
+	}
+	if err := test.Scan("test2"); err == nil {
+		panic("could scan invalid value")
+	}
+	if err := test.Scan(nil); err == nil {
+		panic("could scan nil")
+	}
+
+	if v, err := TestTest2.Value(); err != nil || v != "hello" {
+		panic(fmt.Sprintf("could not get value: %v", err))
+	}
+	if _, err := Test("test2").Value(); err == nil {
+		panic("could get value of invalid value")
+	}
+}
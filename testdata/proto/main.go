@@ -0,0 +1,40 @@
+// extra-parameters: --proto Country=example.com/proto/pb.Country --type Country
+package main
+
+import (
+	"fmt"
+
+	"example.com/proto/pb"
+)
+
+// Country is a test type
+type Country string
+
+// Some Countries
+const (
+	CountryCanada       Country = "CA"
+	CountrySweden       Country = "SE"
+	CountryUnitedStates Country = "US"
+)
+
+func main() {
+	for country, proto := range map[Country]pb.Country{
+		CountryCanada:       pb.Country_COUNTRY_CANADA,
+		CountrySweden:       pb.Country_COUNTRY_SWEDEN,
+		CountryUnitedStates: pb.Country_COUNTRY_UNITED_STATES,
+	} {
+		if got := country.ToProto(); got != proto {
+			panic(fmt.Sprintf("wrong protobuf value of %s: %d", country, got))
+		}
+		if got, ok := CountryFromProto(proto); !ok || got != country {
+			panic(fmt.Sprintf("wrong value of protobuf value %d: %s", proto, got))
+		}
+	}
+
+	if got := Country("XX").ToProto(); got != pb.Country_COUNTRY_UNSPECIFIED {
+		panic(fmt.Sprintf("invalid value should not have a protobuf value: %d", got))
+	}
+	if _, ok := CountryFromProto(pb.Country_COUNTRY_UNSPECIFIED); ok {
+		panic("the unspecified protobuf value should not have a value")
+	}
+}
@@ -0,0 +1,21 @@
+// Package pb mimics the code generated by protoc-gen-go for an enum
+package pb
+
+// Country is a protobuf enum
+type Country int32
+
+// The values of Country
+const (
+	Country_COUNTRY_UNSPECIFIED   Country = 0
+	Country_COUNTRY_CANADA        Country = 1
+	Country_COUNTRY_SWEDEN        Country = 2
+	Country_COUNTRY_UNITED_STATES Country = 3
+)
+
+// Country_name maps the values of Country to their names
+var Country_name = map[int32]string{
+	0: "COUNTRY_UNSPECIFIED",
+	1: "COUNTRY_CANADA",
+	2: "COUNTRY_SWEDEN",
+	3: "COUNTRY_UNITED_STATES",
+}
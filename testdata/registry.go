@@ -0,0 +1,47 @@
+// extra-parameters: --registry --type Test --type Test2
+package main
+
+import (
+	"fmt"
+
+	"github.com/lindell/string-enumer/pkg/registry"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test" // A test
+	TestTest2 Test = "hello"
+)
+
+// Test2 is a test type
+type Test2 string
+
+// Some Test2s
+const (
+	Test2Test Test2 = "test"
+)
+
+func main() {
+	enums := registry.Enums()
+	if len(enums) != 2 || enums[0].Name != "Test" || enums[1].Name != "Test2" {
+		panic(fmt.Sprintf("wrong registered enums: %+v", enums))
+	}
+
+	e, ok := registry.Lookup(enums[0].PkgPath, "Test")
+	if !ok {
+		panic("could not lookup Test")
+	}
+	expected := []registry.Value{
+		{Name: "TestTest", Value: "test", Description: "A test"},
+		{Name: "TestTest2", Value: "hello"},
+	}
+	if len(e.Values) != len(expected) || e.Values[0] != expected[0] || e.Values[1] != expected[1] {
+		panic(fmt.Sprintf("wrong values: %+v", e.Values))
+	}
+	if !e.Valid("hello") || e.Valid("test2") {
+		panic("wrong validation function")
+	}
+}
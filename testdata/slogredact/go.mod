@@ -0,0 +1,3 @@
+module example.com/slogredact
+
+go 1.21
@@ -0,0 +1,36 @@
+// extra-parameters: --type Test --slog --slog-redact
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test"
+	TestTest2 Test = "hello"
+)
+
+func main() {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("valid", "test", TestTest)
+	logger.Info("invalid", "test", Test("<script>garbage</script>"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		panic(fmt.Sprintf("wrong log output:\n%s", buf.String()))
+	}
+	if !strings.Contains(lines[0], `"test":{"value":"test","name":"TestTest","valid":true}`) {
+		panic(fmt.Sprintf("wrong log of valid value: %s", lines[0]))
+	}
+	if !strings.Contains(lines[1], `"test":{"value":"[redacted]","valid":false}`) {
+		panic(fmt.Sprintf("wrong log of invalid value: %s", lines[1]))
+	}
+}
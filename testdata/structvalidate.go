@@ -0,0 +1,118 @@
+// extra-parameters: --struct-validate --type Test --type Test2
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test"
+	TestTest2 Test = "hello"
+)
+
+// Test2 is a test type
+type Test2 string
+
+// Some Test2s
+const (
+	Test2Test Test2 = "test"
+)
+
+// Address is a struct with a nested enum field
+type Address struct {
+	Street string
+	Test   Test
+}
+
+// Tests is a named slice of Tests
+type Tests []Test
+
+// Request is a struct with many different kind of enum fields
+type Request struct {
+	Name     string
+	Test     Test
+	Test2    *Test2
+	Slice    []Test
+	Named    Tests
+	Array    [2]Test
+	Keys     map[Test]int
+	Values   map[string]Test2
+	Address  Address
+	Optional *Address
+	Inline   struct {
+		Test Test
+	}
+	Addresses []*Address
+}
+
+// NoEnums should not get a Validate method
+type NoEnums struct {
+	Name string
+}
+
+func main() {
+	test2 := Test2Test
+	valid := Request{
+		Test:      TestTest,
+		Test2:     &test2,
+		Slice:     []Test{TestTest2},
+		Named:     Tests{TestTest},
+		Array:     [2]Test{TestTest, TestTest2},
+		Keys:      map[Test]int{TestTest: 1},
+		Values:    map[string]Test2{"key": Test2Test},
+		Address:   Address{Test: TestTest},
+		Addresses: []*Address{nil, {Test: TestTest2}},
+	}
+	valid.Inline.Test = TestTest
+	if err := valid.Validate(); err != nil {
+		panic(fmt.Sprintf("should be valid: %s", err))
+	}
+
+	invalidTest2 := Test2("invalid")
+	invalid := Request{
+		Test:      "invalid",
+		Test2:     &invalidTest2,
+		Slice:     []Test{TestTest, "invalid"},
+		Named:     Tests{"invalid"},
+		Array:     [2]Test{TestTest, "invalid"},
+		Keys:      map[Test]int{"invalid": 1},
+		Values:    map[string]Test2{"key": "invalid"},
+		Address:   Address{Test: "invalid"},
+		Optional:  &Address{Test: "invalid"},
+		Addresses: []*Address{{Test: "invalid"}},
+	}
+	invalid.Inline.Test = "invalid"
+	err := invalid.Validate()
+	if err == nil {
+		panic("should not be valid")
+	}
+	for _, path := range []string{
+		"Test: ",
+		"Test2: ",
+		"Slice[1]: ",
+		"Named[0]: ",
+		"Array[1]: ",
+		"Keys[invalid]: ",
+		"Values[key]: ",
+		"Address.Test: ",
+		"Optional.Test: ",
+		"Inline.Test: ",
+		"Addresses[0].Test: ",
+	} {
+		if !strings.Contains(err.Error(), path+"not valid value") {
+			panic(fmt.Sprintf("error does not contain %s: %s", path, err))
+		}
+	}
+	if strings.Contains(err.Error(), "Slice[0]") {
+		panic(fmt.Sprintf("error contains valid field: %s", err))
+	}
+
+	if _, ok := interface{}(NoEnums{}).(interface{ Validate() error }); ok {
+		panic("NoEnums should not have a Validate method")
+	}
+}
@@ -0,0 +1,53 @@
+// extra-parameters: --text --list --type Country
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Country is a country
+type Country string
+
+// Some Countries
+const (
+	CountryCanada  Country = "Canada"
+	CountrySweden  Country = "Sweden"
+	CountrySwitzer Country = "Switzerland"
+	CountryÅland   Country = "Åland"
+	CountryChad    Country = "TD"
+)
+
+func main() {
+	var c Country
+	err := json.Unmarshal([]byte(`"Swedn"`), &c)
+	var invalid InvalidCountryError
+	if !errors.As(err, &invalid) || !reflect.DeepEqual(invalid.Suggestions, []string{"Sweden"}) {
+		panic(fmt.Sprintf("wrong suggestions: %v", err))
+	}
+	if expected := `not valid value for Country: Swedn (allowed values: "Canada", "Sweden", "Switzerland", "Åland", "TD"); did you mean "Sweden"?`; err.Error() != expected {
+		panic(fmt.Sprintf("wrong error message: %s", err))
+	}
+
+	for text, expected := range map[string][]string{
+		"Aland":      {"Åland"},
+		"canda":      {"Canada"},
+		"Switzerlnd": {"Switzerland"},
+		"XY":         nil, // Every character of TD would be replaced
+		"Germany":    nil,
+		"":           nil,
+	} {
+		_, err := ParseCountry(text)
+		if !errors.As(err, &invalid) || !reflect.DeepEqual(invalid.Suggestions, expected) {
+			panic(fmt.Sprintf("wrong suggestions for %q: %v", text, err))
+		}
+	}
+
+	var list CountryList
+	err = list.UnmarshalText([]byte("Canada,Swden"))
+	if !errors.As(err, &invalid) || !reflect.DeepEqual(invalid.Suggestions, []string{"Sweden"}) {
+		panic(fmt.Sprintf("list elements should have suggestions: %v", err))
+	}
+}
@@ -0,0 +1,58 @@
+// extra-parameters: --type country --text --env --html --completion --orm --format --names --fuzz
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type country string
+
+const (
+	countryCanada country = "CA" // Canada
+	countrySweden country = "SE" // Sweden
+)
+
+func main() {
+	values := countryValues()
+	if len(values) != 2 || countryCount != 2 || countryValueAt(1) != countrySweden {
+		panic(fmt.Sprintf("wrong values: %v", values))
+	}
+
+	if _, err := parseCountry("SE"); err != nil {
+		panic(err)
+	}
+	if _, err := parseCountry("US"); err == nil {
+		panic("should not parse an invalid value")
+	}
+
+	var c country
+	if err := json.Unmarshal([]byte(`"CA"`), &c); err != nil || c != countryCanada {
+		panic(fmt.Sprintf("could not unmarshal country: %v", err))
+	}
+	if err := c.Decode("SE"); err != nil || c != countrySweden {
+		panic(fmt.Sprintf("could not decode country: %v", err))
+	}
+	if err := c.Scan("CA"); err != nil || c != countryCanada {
+		panic(fmt.Sprintf("could not scan country: %v", err))
+	}
+
+	if options := countryOptions(countrySweden); len(options) != 2 || !options[1].Selected {
+		panic(fmt.Sprintf("wrong options: %v", options))
+	}
+	if _, err := countryFromFormValue("CA"); err != nil {
+		panic(err)
+	}
+	if completions := countryCompletions("S"); len(completions) != 1 {
+		panic(fmt.Sprintf("wrong completions: %v", completions))
+	}
+	if help := countryEnvHelp(); help == "" {
+		panic("missing env help")
+	}
+	if v, ok := countryFromName("Sweden"); !ok || v != countrySweden || countryNames()[0] != "countryCanada" {
+		panic("could not get country from name")
+	}
+	if s := fmt.Sprintf("%#v", countryCanada); s != "main.countryCanada" {
+		panic(fmt.Sprintf("wrong Go syntax representation: %s", s))
+	}
+}
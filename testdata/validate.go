@@ -0,0 +1,44 @@
+// extra-parameters: --validate --text --type Test
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Test is a test type
+type Test string
+
+// Some Tests
+const (
+	TestTest  Test = "test"
+	TestTest2 Test = "hello"
+)
+
+// validatable is the interface that validation libraries, e.g. ozzo-validation, look for
+type validatable interface {
+	Validate() error
+}
+
+func main() {
+	var v validatable = TestTest2
+	if err := v.Validate(); err != nil {
+		panic(fmt.Sprintf("valid value should not have an error: %s", err))
+	}
+
+	err := Test("world").Validate()
+	var invalid InvalidTestError
+	if !errors.As(err, &invalid) || invalid.Value != "world" {
+		panic(fmt.Sprintf("wrong error of invalid value: %v", err))
+	}
+	if expected := `not valid value for Test: world (allowed values: "test", "hello")`; err.Error() != expected {
+		panic(fmt.Sprintf("wrong error message: %s", err))
+	}
+
+	var t Test
+	err = json.Unmarshal([]byte(`"world"`), &t)
+	if !errors.As(err, &invalid) {
+		panic(fmt.Sprintf("unmarshaling should return the typed error: %v", err))
+	}
+}